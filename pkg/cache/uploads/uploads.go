@@ -0,0 +1,149 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package uploads persists the progress of in-progress chunked blob uploads to disk,
+// modeled on the tus.io resumable-upload protocol, so a `kit push` killed mid-upload
+// can confirm how much the registry already has and continue from there instead of
+// restarting from byte zero.
+package uploads
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kitops-ml/kitops/pkg/lib/constants"
+)
+
+// Key identifies a single resumable upload session: one blob, to one repository, on
+// one registry. State is stored keyed by these three fields so that concurrent or
+// unrelated uploads never collide.
+type Key struct {
+	Registry   string
+	Repository string
+	Digest     string
+}
+
+// State is the resumable-upload progress persisted after every successful chunk.
+type State struct {
+	// Digest is the blob being uploaded, repeated here (in addition to being part of
+	// Key) so a state file is self-describing if found without its Key.
+	Digest string `json:"digest"`
+	// StartingURL is the upload location returned by the initial POST, kept for
+	// debugging; resuming always continues from NextLocation, not this.
+	StartingURL string `json:"startingUrl"`
+	// NextLocation is the upload location to PATCH (or, if the upload is otherwise
+	// complete, PUT) to continue the session, as returned by the most recent request.
+	NextLocation string `json:"nextLocation"`
+	// LastRangeEnd is the last (inclusive) byte offset the registry has confirmed
+	// receiving, used only for diagnostics; resuming re-derives the offset from the
+	// registry's own upload-status (GET) response rather than trusting this blindly.
+	LastRangeEnd int64 `json:"lastRangeEnd"`
+	// ChunkSize is the chunk size in use for this session, so resuming keeps using it
+	// even if the configured default has since changed.
+	ChunkSize int64 `json:"chunkSize"`
+}
+
+// Load reads the persisted State for key, if any. A missing state file is not an
+// error: ok is false and err is nil, meaning there's no upload to resume.
+func Load(cacheDir string, key Key) (state *State, ok bool, err error) {
+	data, err := os.ReadFile(path(cacheDir, key))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read upload state: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, false, fmt.Errorf("failed to parse upload state: %w", err)
+	}
+	return &s, true, nil
+}
+
+// Save persists state for key, overwriting any previously-saved state for the same
+// key. It's called after every successful chunk PATCH so a killed process loses at
+// most the in-flight chunk.
+func Save(cacheDir string, key Key, state State) error {
+	p := path(cacheDir, key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create uploads cache directory: %w", err)
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload state: %w", err)
+	}
+	if err := os.WriteFile(p, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write upload state: %w", err)
+	}
+	return nil
+}
+
+// Clear removes any persisted state for key. It must be called once an upload
+// completes (the final PUT succeeds) or once the registry reports the upload session
+// no longer exists (404 BlobUploadUnknown), so a stale session can't wedge a future
+// push into resuming from a location the registry has already forgotten. A missing
+// state file is not an error.
+func Clear(cacheDir string, key Key) error {
+	if err := os.Remove(path(cacheDir, key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear upload state: %w", err)
+	}
+	return nil
+}
+
+// List enumerates every persisted upload state file under cacheDir, for a `kit push
+// --list-resumable`-style command to show the user what's resumable without having to
+// know any digests up front. A cacheDir that doesn't have an uploads subdirectory yet
+// (no upload has ever been interrupted) returns an empty slice, not an error.
+func List(cacheDir string) ([]State, error) {
+	root := filepath.Join(cacheDir, constants.UploadsSubpath)
+	var states []State
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(p) != ".json" {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to read upload state %s: %w", p, err)
+		}
+		var s State
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("failed to parse upload state %s: %w", p, err)
+		}
+		states = append(states, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// path returns the on-disk location for key's state file, under
+// <cacheDir>/uploads/<registry>/<repository>/<digest>.json.
+func path(cacheDir string, key Key) string {
+	digestFile := strings.ReplaceAll(key.Digest, ":", "_") + ".json"
+	return filepath.Join(cacheDir, constants.UploadsSubpath, key.Registry, filepath.FromSlash(key.Repository), digestFile)
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uploads
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveLoadClear(t *testing.T) {
+	cacheDir := t.TempDir()
+	key := Key{Registry: "registry.example.com", Repository: "org/model", Digest: "sha256:abc123"}
+
+	_, ok, err := Load(cacheDir, key)
+	assert.NoError(t, err)
+	assert.False(t, ok, "should have no state before it's ever been saved")
+
+	state := State{
+		Digest:       key.Digest,
+		StartingURL:  "https://registry.example.com/v2/org/model/blobs/uploads/one",
+		NextLocation: "https://registry.example.com/v2/org/model/blobs/uploads/two",
+		LastRangeEnd: 1023,
+		ChunkSize:    1024,
+	}
+	assert.NoError(t, Save(cacheDir, key, state))
+
+	loaded, ok, err := Load(cacheDir, key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, &state, loaded)
+
+	assert.NoError(t, Clear(cacheDir, key))
+	_, ok, err = Load(cacheDir, key)
+	assert.NoError(t, err)
+	assert.False(t, ok, "state should be gone after Clear")
+
+	// Clearing a key with no state is not an error.
+	assert.NoError(t, Clear(cacheDir, key))
+}
+
+func TestSaveOverwritesPriorState(t *testing.T) {
+	cacheDir := t.TempDir()
+	key := Key{Registry: "registry.example.com", Repository: "org/model", Digest: "sha256:abc123"}
+
+	assert.NoError(t, Save(cacheDir, key, State{NextLocation: "one", LastRangeEnd: 0}))
+	assert.NoError(t, Save(cacheDir, key, State{NextLocation: "two", LastRangeEnd: 1023}))
+
+	loaded, ok, err := Load(cacheDir, key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "two", loaded.NextLocation)
+	assert.Equal(t, int64(1023), loaded.LastRangeEnd)
+}
+
+func TestListEnumeratesPersistedState(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	states, err := List(cacheDir)
+	assert.NoError(t, err)
+	assert.Empty(t, states, "a cache dir with no uploads subdirectory yet should list as empty, not error")
+
+	keyA := Key{Registry: "registry.example.com", Repository: "org/model-a", Digest: "sha256:abc123"}
+	keyB := Key{Registry: "registry.example.com", Repository: "org/nested/model-b", Digest: "sha256:def456"}
+	assert.NoError(t, Save(cacheDir, keyA, State{Digest: keyA.Digest, NextLocation: "a"}))
+	assert.NoError(t, Save(cacheDir, keyB, State{Digest: keyB.Digest, NextLocation: "b"}))
+
+	states, err = List(cacheDir)
+	assert.NoError(t, err)
+	assert.Len(t, states, 2)
+	var digests []string
+	for _, s := range states {
+		digests = append(digests, s.Digest)
+	}
+	assert.ElementsMatch(t, []string{keyA.Digest, keyB.Digest}, digests)
+}
+
+func TestKeysWithSlashesDontCollide(t *testing.T) {
+	cacheDir := t.TempDir()
+	keyA := Key{Registry: "registry.example.com", Repository: "org/model-a", Digest: "sha256:abc123"}
+	keyB := Key{Registry: "registry.example.com", Repository: "org/model-b", Digest: "sha256:abc123"}
+
+	assert.NoError(t, Save(cacheDir, keyA, State{NextLocation: "a"}))
+	assert.NoError(t, Save(cacheDir, keyB, State{NextLocation: "b"}))
+
+	loadedA, _, err := Load(cacheDir, keyA)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", loadedA.NextLocation)
+
+	loadedB, _, err := Load(cacheDir, keyB)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", loadedB.NextLocation)
+}
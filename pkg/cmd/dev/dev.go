@@ -19,11 +19,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io/fs"
 	"os"
 	"os/signal"
-	"path/filepath"
-	"strings"
 	"syscall"
 
 	"github.com/kitops-ml/kitops/pkg/artifact"
@@ -45,12 +42,14 @@ func runDev(ctx context.Context, options *DevStartOptions) error {
 		if err := extractModelKitToCache(signalCtx, options); err != nil {
 			return fmt.Errorf("failed to extract ModelKit: %w", err)
 		}
-		// If a signal was received right after extraction, clean up and stop here
+		// Extraction is resumable (see extractModelKitToCache's Resume option), so an
+		// interrupt here is not a failure: leave the partially-extracted cache and its
+		// .kitops-extract-state.json sidecar in place rather than wiping multiple
+		// gigabytes of progress, and let the user re-run `kit dev` to pick up where it
+		// left off. Only `kit dev stop` (stopDev) or a genuine extraction error clean
+		// up the cache directory.
 		if err := signalCtx.Err(); err != nil {
-			output.Infof("Interrupted, cleaning up cache...")
-			if cleanupErr := options.cleanup(); cleanupErr != nil {
-				output.Debugf("Failed to cleanup cache directory: %v", cleanupErr)
-			}
+			output.Infof("Interrupted during extraction; re-run `kit dev` to resume, or `kit dev stop` to clean up")
 			return signalCtx.Err()
 		}
 	}
@@ -80,26 +79,47 @@ func runDev(ctx context.Context, options *DevStartOptions) error {
 		return err
 	}
 
-	modelPath, err := findModelFile(modelAbsPath)
+	runtime, entrypoint, err := selectRuntime(modelAbsPath, options.runtime)
 	if err != nil {
 		return err
 	}
+	output.Infof("Starting %s runtime for %s", runtime.Name(), entrypoint)
 
-	llmHarness := &harness.LLMHarness{}
-	llmHarness.Host = options.host
-	llmHarness.Port = options.port
-	llmHarness.ConfigHome = options.configHome
-	if err := llmHarness.Init(); err != nil {
-		return err
+	harnessOpts := harness.HarnessOptions{
+		Host:       options.host,
+		Port:       options.port,
+		ConfigHome: options.configHome,
 	}
-
-	if err := llmHarness.Start(modelPath); err != nil {
+	if err := runtime.Start(signalCtx, entrypoint, harnessOpts); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// selectRuntime picks the ModelRuntime to serve the model at absPath: runtimeOverride
+// forces a specific runtime by name (from the `kit dev --runtime` flag), and an empty
+// override falls back to the highest-confidence match among harness.DefaultRuntimes.
+func selectRuntime(absPath, runtimeOverride string) (harness.ModelRuntime, string, error) {
+	runtimes := harness.DefaultRuntimes()
+	if runtimeOverride == "" {
+		return harness.SelectRuntime(absPath, runtimes)
+	}
+
+	rt, err := harness.RuntimeByName(runtimeOverride, runtimes)
+	if err != nil {
+		return nil, "", err
+	}
+	score, entrypoint, err := rt.Detect(absPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("runtime %s does not support %s: %w", runtimeOverride, absPath, err)
+	}
+	if score <= 0 {
+		return nil, "", fmt.Errorf("runtime %s does not recognize a model at %s", runtimeOverride, absPath)
+	}
+	return rt, entrypoint, nil
+}
+
 func stopDev(_ context.Context, options *DevBaseOptions) error {
 
 	// Don't fail stopDev if harness is not running.
@@ -136,40 +156,6 @@ func stopDev(_ context.Context, options *DevBaseOptions) error {
 	return nil
 }
 
-func findModelFile(absPath string) (string, error) {
-	stat, err := os.Lstat(absPath)
-	if err != nil {
-		return "", err
-	}
-	if stat.Mode().IsRegular() {
-		// model path refers to a regular file; assume it's fine to use
-		return absPath, nil
-	} else if !stat.IsDir() {
-		return "", fmt.Errorf("could not find model file in %s: path is not regular file or directory", absPath)
-	}
-
-	modelPath := ""
-	if err := filepath.WalkDir(absPath, func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-		if strings.HasSuffix(path, ".gguf") && d.Type().IsRegular() {
-			if modelPath == "" {
-				modelPath = path
-			} else {
-				return fmt.Errorf("multiple model files found: %s and %s", modelPath, path)
-			}
-		}
-		return nil
-	}); err != nil {
-		return "", fmt.Errorf("error searching for model file in %s: %w", absPath, err)
-	} else if modelPath == "" {
-		return "", fmt.Errorf("could not find model file in %s", absPath)
-	}
-	output.Debugf("Found model path in directory %s at %s", absPath, modelPath)
-	return modelPath, nil
-}
-
 // extractModelKitToCache extracts a ModelKit reference to a cache directory
 // using the unpack library with model filter
 func extractModelKitToCache(ctx context.Context, options *DevStartOptions) error {
@@ -182,12 +168,15 @@ func extractModelKitToCache(ctx context.Context, options *DevStartOptions) error
 	}
 	options.contextDir = extractDir
 
-	// Extract the ModelKit using the library directly
+	// Extract the ModelKit using the library directly. Resume lets a killed or
+	// Ctrl-C'd extraction pick back up from its .kitops-extract-state.json sidecar
+	// instead of redownloading every layer of a multi-gigabyte model from scratch.
 	libOpts := &unpack.UnpackOptions{
 		ModelRef:       options.modelRef,
 		UnpackDir:      extractDir,
 		ConfigHome:     options.configHome,
 		Overwrite:      true, // Safe for extraction directory
+		Resume:         true,
 		NetworkOptions: options.NetworkOptions,
 	}
 
@@ -200,6 +189,11 @@ func extractModelKitToCache(ctx context.Context, options *DevStartOptions) error
 
 	err = unpack.UnpackModelKit(ctx, libOpts)
 	if err != nil {
+		if ctx.Err() != nil {
+			// Interrupted, not a genuine extraction failure: leave the cache and its
+			// resume state in place rather than wiping progress on every Ctrl-C.
+			return fmt.Errorf("failed to extract ModelKit: %w", err)
+		}
 		cleanUpErr := os.RemoveAll(extractDir)
 		if cleanUpErr != nil {
 			return errors.Join(
@@ -0,0 +1,94 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package license implements the `kit license check` command, which evaluates a
+// Kitfile against a pkg/lib/policy/license policy file and reports violations.
+package license
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+	"github.com/kitops-ml/kitops/pkg/lib/policy/license"
+	"github.com/kitops-ml/kitops/pkg/output"
+)
+
+// CheckOptions configures `kit license check`.
+type CheckOptions struct {
+	modelFile  string
+	policyFile string
+	jsonOutput bool
+}
+
+// runCheck loads the Kitfile and policy at options' paths, evaluates one against the
+// other, prints the report, and returns an error if any violations were found so the
+// command exits non-zero.
+func runCheck(options *CheckOptions) error {
+	kitfile := &artifact.KitFile{}
+	modelfile, err := os.Open(options.modelFile)
+	if err != nil {
+		return fmt.Errorf("failed to open Kitfile: %w", err)
+	}
+	defer modelfile.Close()
+	if err := kitfile.LoadModel(modelfile); err != nil {
+		return fmt.Errorf("failed to parse Kitfile: %w", err)
+	}
+
+	policyFile, err := os.Open(options.policyFile)
+	if err != nil {
+		return fmt.Errorf("failed to open license policy: %w", err)
+	}
+	defer policyFile.Close()
+	policy, err := license.LoadPolicy(policyFile)
+	if err != nil {
+		return err
+	}
+
+	report, err := license.Evaluate(kitfile, policy)
+	if err != nil {
+		return err
+	}
+
+	if err := printReport(report, options.jsonOutput); err != nil {
+		return err
+	}
+	if report.HasViolations() {
+		return fmt.Errorf("license policy violations found (%d)", len(report.Violations))
+	}
+	return nil
+}
+
+func printReport(report *license.Report, jsonOutput bool) error {
+	if jsonOutput {
+		out, err := json.MarshalIndent(report.Violations, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode license report: %w", err)
+		}
+		_, err = os.Stdout.Write(append(out, '\n'))
+		return err
+	}
+
+	if !report.HasViolations() {
+		output.Infof("No license policy violations found")
+		return nil
+	}
+	for _, v := range report.Violations {
+		output.Infof("%s (%s): %s [rule: %s]", v.Path, v.License, v.Reason, v.Root)
+	}
+	return nil
+}
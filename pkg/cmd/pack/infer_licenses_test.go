@@ -0,0 +1,68 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+	"github.com/kitops-ml/kitops/pkg/lib/licensedetect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunInferLicensesNoopWithoutFlag(t *testing.T) {
+	dir := t.TempDir()
+	kitfilePath := writeKitfile(t, dir, "model:\n  path: model\n")
+
+	assert.NoError(t, runInferLicenses(&InferLicensesOptions{
+		InferLicenses: false,
+		ContextDir:    dir,
+		KitfilePath:   kitfilePath,
+	}))
+
+	contents, err := os.ReadFile(kitfilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "model:\n  path: model\n", string(contents))
+}
+
+func TestRunInferLicensesFillsUnknown(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "model"), 0755))
+	kitfilePath := writeKitfile(t, dir, "model:\n  path: model\n")
+
+	assert.NoError(t, runInferLicenses(&InferLicensesOptions{
+		InferLicenses: true,
+		ContextDir:    dir,
+		KitfilePath:   kitfilePath,
+	}))
+
+	kitfile := &artifact.KitFile{}
+	f, err := os.Open(kitfilePath)
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.NoError(t, kitfile.LoadModel(f))
+	assert.Equal(t, licensedetect.UnknownLicense, kitfile.Model.License)
+}
+
+func writeKitfile(t *testing.T, dir, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, "Kitfile")
+	assert.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pack
+
+import (
+	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
+)
+
+// ManifestFormatOptions configures `kit pack --manifest-format=kitops|modelpack`.
+// push reuses the same flag: it re-packs nothing, but a ModelKit already built with
+// --manifest-format=modelpack pushes those CNCF media types as-is. unpack and dev
+// need no equivalent flag -- they already dispatch on a layer's BaseType through the
+// MediaType interface (see pkg/lib/filesystem/unpack/core.go), so a kitops-native or
+// ModelPack-compliant manifest unpacks the same way either way.
+type ManifestFormatOptions struct {
+	// ManifestFormat selects the media type family layerMediaType builds. The zero
+	// value behaves as mediatype.KitopsFormat, matching every ModelKit this tree
+	// produced before this flag existed.
+	ManifestFormat mediatype.ManifestFormat
+}
+
+// layerMediaType builds the MediaType a packed layer of base/comp should carry,
+// honoring options.ManifestFormat, in place of mediatype.NewKit wherever a caller needs
+// to respect --manifest-format rather than always writing the kitops-native family.
+func layerMediaType(options *ManifestFormatOptions, base mediatype.BaseType, comp mediatype.CompressionType) mediatype.MediaType {
+	return mediatype.New(options.ManifestFormat, base, comp)
+}
+
+// ConfigMediaType returns the media type a packed manifest's config blob should carry
+// under options.ManifestFormat, for KitManifestVersion1_0's real config blob (see
+// pack.PackModelKitManifestForFormat); KitManifestVersion1_1 ignores it in favor of the
+// OCI empty-config sentinel.
+func (options *ManifestFormatOptions) ConfigMediaType() string {
+	return layerMediaType(options, mediatype.ConfigBaseType, mediatype.NoneCompression).String()
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// PackModelKitManifestOptions collects the pieces of a ModelKit manifest that don't
+// depend on which mediatype.PackManifestVersion is being packed.
+type PackModelKitManifestOptions struct {
+	// Layers are the already-pushed layer descriptors the manifest should reference.
+	Layers []ocispec.Descriptor
+	// ConfigMediaType is the media type pushed for the manifest's config blob under
+	// mediatype.KitManifestVersion1_0. Ignored under KitManifestVersion1_1, which
+	// always uses the OCI empty-config sentinel in its place.
+	ConfigMediaType string
+	// ConfigContent is the config blob pushed under KitManifestVersion1_0. Ignored
+	// under KitManifestVersion1_1.
+	ConfigContent []byte
+	// ManifestAnnotations are set on the manifest itself (not its config or layers).
+	ManifestAnnotations map[string]string
+}
+
+// PackModelKitManifest builds a ModelKit manifest of the requested version, pushes its
+// config and the manifest itself to pusher, and returns the manifest's descriptor. It
+// mirrors oras-go's own oras.PackManifest, dispatching on version the same way that
+// function dispatches on oras.PackManifestVersion: KitManifestVersion1_0 keeps pushing
+// the shape every ModelKit before it produced, while KitManifestVersion1_1 packs the
+// OCI image-spec v1.1 empty-config shape so newly-packed ModelKits interoperate with
+// generic OCI artifact tooling. mediatype.ModelFormatForManifest resolves either shape
+// back to the same ModelFormat, so callers don't need to know which version produced a
+// manifest they're reading.
+func PackModelKitManifest(ctx context.Context, pusher content.Pusher, version mediatype.PackManifestVersion, artifactType string, opts PackModelKitManifestOptions) (ocispec.Descriptor, error) {
+	switch version {
+	case mediatype.KitManifestVersion1_0:
+		return packManifestV1_0(ctx, pusher, artifactType, opts)
+	case mediatype.KitManifestVersion1_1:
+		return packManifestV1_1(ctx, pusher, artifactType, opts)
+	default:
+		return ocispec.Descriptor{}, fmt.Errorf("unsupported pack manifest version: %s", version)
+	}
+}
+
+// PackModelKitManifestForFormat builds and pushes a ModelKit manifest the same way
+// PackModelKitManifest does, deriving the version-1.0 config media type and the
+// version-1.1 artifactType from formatOpts.ManifestFormat so the caller doesn't need
+// its own kitops-vs-modelpack switch alongside --manifest-format.
+func PackModelKitManifestForFormat(ctx context.Context, pusher content.Pusher, formatOpts *ManifestFormatOptions, version mediatype.PackManifestVersion, configContent []byte, layers []ocispec.Descriptor, manifestAnnotations map[string]string) (ocispec.Descriptor, error) {
+	return PackModelKitManifest(ctx, pusher, version, artifactTypeForFormat(formatOpts.ManifestFormat), PackModelKitManifestOptions{
+		Layers:              layers,
+		ConfigMediaType:     formatOpts.ConfigMediaType(),
+		ConfigContent:       configContent,
+		ManifestAnnotations: manifestAnnotations,
+	})
+}
+
+// artifactTypeForFormat returns the ArtifactType a KitManifestVersion1_1 manifest
+// should carry for format, mirroring the family layerMediaType already builds layers
+// in for the same format.
+func artifactTypeForFormat(format mediatype.ManifestFormat) string {
+	if format == mediatype.ModelPackFormat {
+		return mediatype.ArtifactTypeModelManifest
+	}
+	return mediatype.ArtifactTypeKitManifest
+}
+
+// packManifestV1_0 pushes a real config blob and relies on its media type as the
+// discriminator mediatype.ModelFormatForManifest falls back to when no ArtifactType is
+// set, matching every ModelKit this tree produced before ArtifactType existed.
+func packManifestV1_0(ctx context.Context, pusher content.Pusher, artifactType string, opts PackModelKitManifestOptions) (ocispec.Descriptor, error) {
+	configDesc, err := pushBlob(ctx, pusher, opts.ConfigMediaType, opts.ConfigContent)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push manifest config: %w", err)
+	}
+	manifest := ocispec.Manifest{
+		MediaType:   ocispec.MediaTypeImageManifest,
+		Config:      configDesc,
+		Layers:      opts.Layers,
+		Annotations: opts.ManifestAnnotations,
+	}
+	return pushManifest(ctx, pusher, "", manifest)
+}
+
+// packManifestV1_1 packs the OCI image-spec v1.1 shape: an empty config descriptor
+// (mediatype.IsEmptyConfig) and artifactType as the manifest's sole discriminator.
+func packManifestV1_1(ctx context.Context, pusher content.Pusher, artifactType string, opts PackModelKitManifestOptions) (ocispec.Descriptor, error) {
+	if artifactType == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("artifactType is required for manifest version %s", mediatype.KitManifestVersion1_1)
+	}
+	if _, err := pushBlob(ctx, pusher, ocispec.DescriptorEmptyJSON.MediaType, []byte("{}")); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push empty manifest config: %w", err)
+	}
+	manifest := ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: artifactType,
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       opts.Layers,
+		Annotations:  opts.ManifestAnnotations,
+	}
+	return pushManifest(ctx, pusher, artifactType, manifest)
+}
+
+// pushBlob pushes content to pusher under mediaType, returning the descriptor pusher
+// now holds the content under.
+func pushBlob(ctx context.Context, pusher content.Pusher, mediaType string, content []byte) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	if err := pusher.Push(ctx, desc, bytes.NewReader(content)); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// pushManifest marshals manifest, pushes it to pusher, and returns its descriptor,
+// carrying artifactType so a registry that indexes on the descriptor's own
+// ArtifactType field (rather than unmarshaling the manifest body) sees it too.
+func pushManifest(ctx context.Context, pusher content.Pusher, artifactType string, manifest ocispec.Manifest) (ocispec.Descriptor, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	desc := ocispec.Descriptor{
+		MediaType:    manifest.MediaType,
+		ArtifactType: artifactType,
+		Digest:       digest.FromBytes(data),
+		Size:         int64(len(data)),
+	}
+	if err := pusher.Push(ctx, desc, bytes.NewReader(data)); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to push manifest: %w", err)
+	}
+	return desc, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pack
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2/content/memory"
+)
+
+func TestPackModelKitManifestV1_0UsesConfigMediaType(t *testing.T) {
+	store := memory.New()
+	desc, err := PackModelKitManifest(t.Context(), store, mediatype.KitManifestVersion1_0, "", PackModelKitManifestOptions{
+		ConfigMediaType: "application/vnd.kitops.modelkit.config.v1+json",
+		ConfigContent:   []byte(`{"name":"test"}`),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	manifest := fetchManifest(t, store, desc)
+	assert.Empty(t, manifest.ArtifactType)
+	assert.Equal(t, "application/vnd.kitops.modelkit.config.v1+json", manifest.Config.MediaType)
+	assert.False(t, mediatype.IsEmptyConfig(manifest.Config))
+}
+
+func TestPackModelKitManifestV1_1UsesEmptyConfigAndArtifactType(t *testing.T) {
+	store := memory.New()
+	desc, err := PackModelKitManifest(t.Context(), store, mediatype.KitManifestVersion1_1, mediatype.ArtifactTypeKitManifest, PackModelKitManifestOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, mediatype.ArtifactTypeKitManifest, desc.ArtifactType)
+
+	manifest := fetchManifest(t, store, desc)
+	assert.Equal(t, mediatype.ArtifactTypeKitManifest, manifest.ArtifactType)
+	assert.True(t, mediatype.IsEmptyConfig(manifest.Config))
+}
+
+func TestPackModelKitManifestV1_1RequiresArtifactType(t *testing.T) {
+	store := memory.New()
+	_, err := PackModelKitManifest(t.Context(), store, mediatype.KitManifestVersion1_1, "", PackModelKitManifestOptions{})
+	assert.Error(t, err)
+}
+
+func TestPackModelKitManifestRoundTripsThroughModelFormatForManifest(t *testing.T) {
+	for _, version := range []mediatype.PackManifestVersion{mediatype.KitManifestVersion1_0, mediatype.KitManifestVersion1_1} {
+		store := memory.New()
+		desc, err := PackModelKitManifest(t.Context(), store, version, mediatype.ArtifactTypeKitManifest, PackModelKitManifestOptions{
+			ConfigMediaType: "application/vnd.kitops.modelkit.config.v1+json",
+			ConfigContent:   []byte(`{}`),
+		})
+		if !assert.NoError(t, err) {
+			continue
+		}
+		manifest := fetchManifest(t, store, desc)
+		manifest.ArtifactType = desc.ArtifactType
+		format, err := mediatype.ModelFormatForManifest(&manifest)
+		if assert.NoError(t, err, "version %s", version) {
+			assert.Equal(t, mediatype.KitFormat, format, "version %s", version)
+		}
+	}
+}
+
+func TestPackModelKitManifestForFormatUsesManifestFormat(t *testing.T) {
+	cases := []struct {
+		name                string
+		format              mediatype.ManifestFormat
+		wantArtifactType    string
+		wantConfigMediaType string
+	}{
+		{"kitops", mediatype.KitopsFormat, mediatype.ArtifactTypeKitManifest, "application/vnd.kitops.modelkit.config.v1+json"},
+		{"modelpack", mediatype.ModelPackFormat, mediatype.ArtifactTypeModelManifest, "application/vnd.cncf.model.config.v1+json"},
+	}
+	for _, tc := range cases {
+		store := memory.New()
+		formatOpts := &ManifestFormatOptions{ManifestFormat: tc.format}
+		desc, err := PackModelKitManifestForFormat(t.Context(), store, formatOpts, mediatype.KitManifestVersion1_0, []byte(`{"name":"test"}`), nil, nil)
+		if !assert.NoError(t, err, "format %s", tc.name) {
+			continue
+		}
+		manifest := fetchManifest(t, store, desc)
+		assert.Equal(t, tc.wantConfigMediaType, manifest.Config.MediaType, "format %s", tc.name)
+
+		artifactType := artifactTypeForFormat(tc.format)
+		assert.Equal(t, tc.wantArtifactType, artifactType, "format %s", tc.name)
+	}
+}
+
+func fetchManifest(t *testing.T, store *memory.Store, desc ocispec.Descriptor) ocispec.Manifest {
+	t.Helper()
+	rc, err := store.Fetch(context.Background(), desc)
+	if !assert.NoError(t, err) {
+		return ocispec.Manifest{}
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	assert.NoError(t, err)
+	var manifest ocispec.Manifest
+	assert.NoError(t, json.Unmarshal(data, &manifest))
+	return manifest
+}
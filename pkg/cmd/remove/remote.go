@@ -14,6 +14,19 @@
 //
 // SPDX-License-Identifier: Apache-2.0
 
+// Package remove implements `kit remove`'s remote-registry removal, including
+// `kit remove --dangling`.
+//
+// KNOWN LIMITATION -- `--dangling` is NOT full orphan garbage collection. It only
+// removes the tag/digest references a user explicitly passes it, plus the Referrers
+// (SBOM, signature, attestation manifests) that removing those specific references is
+// about to orphan. It does not scan the repository for manifests that were already
+// dangling before the command ran, because the OCI Distribution spec has no catalog
+// endpoint for "every manifest in this repository, tagged or not" to scan with. A user
+// running `kit remove --dangling` expecting it to reclaim pre-existing orphaned
+// ModelKit tags -- e.g. in a CI pipeline that's been retagging and pruning for a
+// while -- will not get those back; only this invocation's own fallout is cleaned up.
+// See removeDanglingForRefs for the implementation this limitation applies to.
 package remove
 
 import (
@@ -21,10 +34,15 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"path"
+	"runtime"
+	"strings"
+	"sync"
 
 	"github.com/kitops-ml/kitops/pkg/lib/repo/remote"
 	"github.com/kitops-ml/kitops/pkg/lib/repo/util"
 	"github.com/kitops-ml/kitops/pkg/output"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry"
@@ -36,28 +54,181 @@ func removeRemoteModel(ctx context.Context, opts *removeOptions) error {
 	if err != nil {
 		return err
 	}
+	return removeOneRemoteModel(ctx, repository, *opts.modelRef, opts.forceDelete)
+}
+
+// removeRemoteModels is the batch entrypoint behind `kit remove` accepting more than
+// one reference: opts.modelRefs may mix plain tag or digest refs and tag globs (e.g.
+// "myrepo:v1.*"). A repository-only ref naming nothing but a registry/repository pair
+// fails to resolve, with or without opts.dangling. It resolves every ref to a removalTarget up front, then
+// either removes dangling referrers alongside each target or just the targets
+// themselves, both through a bounded worker pool so a large glob expansion doesn't
+// open unbounded concurrent connections to the registry.
+func removeRemoteModels(ctx context.Context, opts *removeOptions) error {
+	if opts.dangling {
+		return removeDanglingForRefs(ctx, opts)
+	}
+
+	resolved, err := resolveRemovalTargets(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return removeResolvedConcurrently(ctx, resolved, opts.forceDelete, removeConcurrency(opts.parallel))
+}
+
+// removalTarget pairs a repository connection with one concrete (non-glob) reference
+// to remove from it.
+type removalTarget struct {
+	repository *remote.Repository
+	ref        registry.Reference
+}
+
+// resolveRemovalTargets parses opts.modelRefs, expanding any tag glob against the
+// repository's current tag list, and returns one removalTarget per concrete
+// reference. Repositories are connected to once per distinct registry/repository
+// pair, even if several of opts.modelRefs target it, so a batch of CI tag globs
+// against one repo doesn't open redundant connections.
+func resolveRemovalTargets(ctx context.Context, opts *removeOptions) ([]removalTarget, error) {
+	type repoKey struct{ registry, repository string }
+	repositories := map[repoKey]*remote.Repository{}
+
+	var targets []removalTarget
+	for _, refStr := range opts.modelRefs {
+		ref, err := registry.ParseReference(refStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference %q: %w", refStr, err)
+		}
+
+		key := repoKey{ref.Registry, ref.Repository}
+		repository, ok := repositories[key]
+		if !ok {
+			repository, err = remote.NewRepository(ctx, ref.Registry, ref.Repository, &opts.NetworkOptions)
+			if err != nil {
+				return nil, err
+			}
+			repositories[key] = repository
+		}
+
+		if !isTagGlob(ref.Reference) {
+			targets = append(targets, removalTarget{repository: repository, ref: ref})
+			continue
+		}
+		matches, err := expandTagGlob(ctx, repository, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand glob %q: %w", refStr, err)
+		}
+		for _, match := range matches {
+			targets = append(targets, removalTarget{repository: repository, ref: match})
+		}
+	}
+	return targets, nil
+}
 
-	desc, err := repository.Resolve(ctx, opts.modelRef.Reference)
+// isTagGlob reports whether ref looks like a glob pattern rather than a literal tag
+// or digest -- i.e. it contains any of the characters path.Match treats specially.
+func isTagGlob(ref string) bool {
+	return strings.ContainsAny(ref, "*?[")
+}
+
+// expandTagGlob lists repository's tags and returns one reference per tag matching
+// ref.Reference as a path.Match pattern.
+func expandTagGlob(ctx context.Context, repository *remote.Repository, ref registry.Reference) ([]registry.Reference, error) {
+	var matches []registry.Reference
+	err := repository.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			matched, err := path.Match(ref.Reference, tag)
+			if err != nil {
+				return err
+			}
+			if matched {
+				match := ref
+				match.Reference = tag
+				matches = append(matches, match)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		output.Infof("No tags in %s/%s matched %q", ref.Registry, ref.Repository, ref.Reference)
+	}
+	return matches, nil
+}
+
+// removeConcurrency returns parallel if positive, falling back to the same
+// CPU-scaled default PushAllOptions.Concurrency uses for the equivalent problem on
+// the push side.
+func removeConcurrency(parallel int) int {
+	if parallel > 0 {
+		return parallel
+	}
+	return min(4, runtime.GOMAXPROCS(0))
+}
+
+// removeResolvedConcurrently removes every target through a bounded worker pool of
+// size concurrency. Unlike PushAll's fail-fast pool, a failure here does not cancel
+// the others: a batch remove should make as much progress as it can, so every
+// target's error (if any) is collected and joined into a single error rather than
+// aborting the remaining deletes.
+func removeResolvedConcurrently(ctx context.Context, targets []removalTarget, forceDelete bool, concurrency int) error {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target removalTarget) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := removeOneRemoteModel(ctx, target.repository, target.ref, forceDelete); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", util.FormatRepositoryForDisplay(target.ref.String()), err))
+				mu.Unlock()
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// removeOneRemoteModel is the single-reference remove logic shared by the one-ref and
+// batch entrypoints.
+func removeOneRemoteModel(ctx context.Context, repository *remote.Repository, ref registry.Reference, forceDelete bool) error {
+	desc, err := repository.Resolve(ctx, ref.Reference)
 	if err != nil {
 		if errors.Is(err, errdef.ErrNotFound) {
-			return fmt.Errorf("model %s not found", util.FormatRepositoryForDisplay(opts.modelRef.String()))
+			return fmt.Errorf("model %s not found", util.FormatRepositoryForDisplay(ref.String()))
 		}
 		return fmt.Errorf("error resolving modelkit: %w", err)
 	}
 
 	// If user supplied a tag instead of a digest, only do an untag; assume the remote will prune untagged ModelKits
-	if !util.ReferenceIsDigest(opts.modelRef.Reference) && !opts.forceDelete {
-		output.Infof("Untagging remote ModelKit %s", util.FormatRepositoryForDisplay(opts.modelRef.String()))
-		return untagRemoteModel(ctx, opts.modelRef.Reference, repository)
+	if !util.ReferenceIsDigest(ref.Reference) && !forceDelete {
+		output.Infof("Untagging remote ModelKit %s", util.FormatRepositoryForDisplay(ref.String()))
+		return untagRemoteModel(ctx, ref.Reference, repository)
 	}
 
 	// Otherwise, delete the ModelKit itself, which will delete all tags that point to it
-	deleteRef := *opts.modelRef
+	deleteRef := ref
 	deleteRef.Reference = desc.Digest.String()
 	output.Infof("Deleting remote ModelKit %s", util.FormatRepositoryForDisplay(deleteRef.String()))
 	if err := repository.Delete(ctx, desc); err != nil {
-		if errResp, ok := err.(*errcode.ErrorResponse); ok && errResp.StatusCode == http.StatusMethodNotAllowed {
-			return fmt.Errorf("removing models is unsupported by registry %s", opts.modelRef.Registry)
+		if isMethodNotAllowed(err) {
+			// Some registries don't support manifest deletion at all; rather than
+			// failing the whole batch over it, degrade to the untag path for this
+			// one manifest if it was reached by tag. A bare digest has nothing to
+			// untag, so it still fails -- there's no way to partially remove it.
+			if !util.ReferenceIsDigest(ref.Reference) {
+				output.Infof("Registry does not support deleting manifests; untagging %s instead", util.FormatRepositoryForDisplay(ref.String()))
+				return untagRemoteModel(ctx, ref.Reference, repository)
+			}
+			return fmt.Errorf("removing models is unsupported by registry %s", ref.Registry)
 		}
 		return fmt.Errorf("failed to remove remote model: %w", err)
 	}
@@ -73,3 +244,85 @@ func untagRemoteModel(ctx context.Context, tag string, repo registry.Repository)
 	}
 	return untaggerRepo.Untag(ctx, tag)
 }
+
+func isMethodNotAllowed(err error) bool {
+	errResp, ok := err.(*errcode.ErrorResponse)
+	return ok && errResp.StatusCode == http.StatusMethodNotAllowed
+}
+
+// removeDanglingForRefs implements `kit remove --dangling`: for each resolved target
+// (a tag, glob, or digest) it deletes the target itself and then any of that target's
+// Referrers -- SBOM, signature, and attestation manifests the OCI Referrers API
+// attaches to it -- since the registry does not cascade-delete those when their
+// subject goes away, leaving them dangling.
+//
+// NOT full orphan garbage collection -- see this package's doc comment. This only
+// catches the referrers that THIS call's own deletions are about to orphan; it cannot
+// discover manifests that were already dangling before the command ran, because the
+// Distribution spec has no catalog endpoint for "every manifest in this repository",
+// tagged or not, to scan against. A CI pipeline that has been retagging and pruning a
+// ModelKit for a while, expecting --dangling to sweep up everything already orphaned,
+// will not get those pre-existing orphans back from this call.
+func removeDanglingForRefs(ctx context.Context, opts *removeOptions) error {
+	targets, err := resolveRemovalTargets(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	concurrency := removeConcurrency(opts.parallel)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+
+	for _, target := range targets {
+		wg.Add(1)
+		go func(target removalTarget) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := removeWithDanglingReferrers(ctx, target.repository, target.ref, opts.forceDelete); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", util.FormatRepositoryForDisplay(target.ref.String()), err))
+				mu.Unlock()
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// removeWithDanglingReferrers resolves ref's referrers before removing it, then
+// removes the referrers too once ref itself is gone.
+func removeWithDanglingReferrers(ctx context.Context, repository *remote.Repository, ref registry.Reference, forceDelete bool) error {
+	desc, err := repository.Resolve(ctx, ref.Reference)
+	if err != nil {
+		if errors.Is(err, errdef.ErrNotFound) {
+			return fmt.Errorf("model %s not found", util.FormatRepositoryForDisplay(ref.String()))
+		}
+		return fmt.Errorf("error resolving modelkit: %w", err)
+	}
+
+	var referrers []ocispec.Descriptor
+	if err := repository.Referrers(ctx, desc, "", func(refs []ocispec.Descriptor) error {
+		referrers = append(referrers, refs...)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to list referrers: %w", err)
+	}
+
+	if err := removeOneRemoteModel(ctx, repository, ref, forceDelete); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, referrer := range referrers {
+		output.Infof("Deleting dangling referrer %s", referrer.Digest)
+		if err := repository.Delete(ctx, referrer); err != nil && !errors.Is(err, errdef.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("failed to remove dangling referrer %s: %w", referrer.Digest, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sbom implements the `kit sbom` command, which generates an SPDX 2.3
+// Software Bill of Materials for a local or remote ModelKit.
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+	"github.com/kitops-ml/kitops/pkg/lib/repo/remote"
+	"github.com/kitops-ml/kitops/pkg/lib/repo/util"
+	"github.com/kitops-ml/kitops/pkg/output"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+)
+
+// OutputFormat selects how SBOMOptions.outputPath is encoded. Tag-value is the other
+// SPDX 2.3-defined serialization, but JSON is the only one GenerateSBOM's result needs
+// to support for this first cut: it's what Syft/Guac and friends already ingest.
+type OutputFormat string
+
+const (
+	FormatJSON OutputFormat = "json"
+)
+
+// SBOMOptions configures `kit sbom`. Exactly one of localPath or modelRef should be
+// set, mirroring how other commands in this tree distinguish a local Kitfile context
+// from a registry reference.
+type SBOMOptions struct {
+	util.NetworkOptions
+
+	configHome string
+	localPath  string
+	modelRef   *registry.Reference
+
+	format     OutputFormat
+	outputPath string
+}
+
+// runSBOM loads the Kitfile and manifest for options' target (local directory or
+// remote reference), generates an SPDX document for it, and writes the result to
+// options.outputPath (or stdout, if unset).
+func runSBOM(ctx context.Context, options *SBOMOptions) error {
+	kitfile, manifest, manifestDigest, ref, err := loadTarget(ctx, options)
+	if err != nil {
+		return err
+	}
+
+	doc, err := GenerateSBOM(ref, manifestDigest, manifest, kitfile, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to generate SBOM: %w", err)
+	}
+
+	out, err := encode(doc, options.format)
+	if err != nil {
+		return fmt.Errorf("failed to encode SBOM: %w", err)
+	}
+
+	if options.outputPath == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	output.Infof("Writing SBOM to %s", options.outputPath)
+	return os.WriteFile(options.outputPath, out, 0644)
+}
+
+// loadTarget resolves options' local path or remote reference into the manifest and
+// Kitfile GenerateSBOM needs, along with the manifest's digest and a display reference
+// for the SBOM's name/downloadLocation fields.
+func loadTarget(ctx context.Context, options *SBOMOptions) (*artifact.KitFile, *ocispec.Manifest, digest.Digest, string, error) {
+	if options.modelRef != nil {
+		return loadRemoteTarget(ctx, options)
+	}
+	return loadLocalTarget(options)
+}
+
+// loadLocalTarget reads the Kitfile at options.localPath directly; there's no
+// manifest for a local, unpushed ModelKit, so the SBOM describes the Kitfile alone
+// and packageVerificationCodes are omitted rather than guessed at.
+func loadLocalTarget(options *SBOMOptions) (*artifact.KitFile, *ocispec.Manifest, digest.Digest, string, error) {
+	f, err := os.Open(options.localPath)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to open Kitfile: %w", err)
+	}
+	defer f.Close()
+
+	kitfile := &artifact.KitFile{}
+	if err := kitfile.LoadModel(f); err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to parse Kitfile: %w", err)
+	}
+	return kitfile, &ocispec.Manifest{}, "", options.localPath, nil
+}
+
+// loadRemoteTarget resolves options.modelRef against the registry, fetching its
+// manifest and the Kitfile embedded in the ModelKit's config layer.
+//
+// Resolution goes through Repository.ResolveManifest rather than a plain fetch+decode
+// so that a reference naming a multi-variant ModelKit (an OCI image index) or a
+// legacy OCI artifact manifest is normalized to the single image manifest this
+// function expects, instead of silently decoding into a mostly-empty ocispec.Manifest.
+// This command doesn't yet expose a --variant/--platform flag of its own, so it
+// always resolves to ResolveManifest's default, erroring if the reference names an
+// index with more than one child and no way to pick between them.
+func loadRemoteTarget(ctx context.Context, options *SBOMOptions) (*artifact.KitFile, *ocispec.Manifest, digest.Digest, string, error) {
+	repository, err := remote.NewRepository(ctx, options.modelRef.Registry, options.modelRef.Repository, &options.NetworkOptions)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	manifestDesc, manifest, err := repository.ResolveManifest(ctx, options.modelRef.Reference, "", nil)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	configBytes, err := content.FetchAll(ctx, repository, manifest.Config)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to fetch Kitfile config: %w", err)
+	}
+	kitfile := &artifact.KitFile{}
+	if err := kitfile.LoadModel(io.NopCloser(bytes.NewReader(configBytes))); err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to parse Kitfile: %w", err)
+	}
+
+	return kitfile, manifest, manifestDesc.Digest, util.FormatRepositoryForDisplay(options.modelRef.String()), nil
+}
+
+// encode renders doc in the format options.format asks for. Tag-value would be added
+// here as a sibling branch once `kit sbom --format tagvalue` is needed.
+func encode(doc *Document, format OutputFormat) ([]byte, error) {
+	switch format {
+	case "", FormatJSON:
+		return json.MarshalIndent(doc, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format %q", format)
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+	modelspecv1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Relationship types used when expressing the model->parts->datasets graph. These are
+// a subset of the SPDX 2.3 relationship vocabulary; we only ever emit these three.
+const (
+	RelationshipDescribes = "DESCRIBES"
+	RelationshipContains  = "CONTAINS"
+	RelationshipDependsOn = "DEPENDS_ON"
+)
+
+// noAssertion is the SPDX placeholder for a field whose real value is unknown, as
+// opposed to one that's deliberately empty (NONE). Every license/copyright field below
+// falls back to it when the Kitfile doesn't populate the corresponding value.
+const noAssertion = "NOASSERTION"
+
+// Document is an SPDX 2.3 document, trimmed to the fields GenerateSBOM populates.
+// Field names and JSON tags follow the SPDX spec's own casing so this struct
+// marshals directly into a conformant SPDX JSON document.
+type Document struct {
+	SPDXVersion       string         `json:"spdxVersion"`
+	DataLicense       string         `json:"dataLicense"`
+	SPDXID            string         `json:"SPDXID"`
+	Name              string         `json:"name"`
+	DocumentNamespace string         `json:"documentNamespace"`
+	CreationInfo      CreationInfo   `json:"creationInfo"`
+	Packages          []Package      `json:"packages"`
+	Relationships     []Relationship `json:"relationships"`
+	DocumentDescribes []string       `json:"documentDescribes"`
+}
+
+// CreationInfo records who/what produced the document and when, per the SPDX spec.
+type CreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+// Package is one SPDX Package element. GenerateSBOM emits one per Kitfile section
+// (the ModelKit itself, the model, each model part, dataset, code entry, and doc).
+type Package struct {
+	SPDXID                  string                   `json:"SPDXID"`
+	Name                    string                   `json:"name"`
+	VersionInfo             string                   `json:"versionInfo,omitempty"`
+	DownloadLocation        string                   `json:"downloadLocation"`
+	FilesAnalyzed           bool                     `json:"filesAnalyzed"`
+	LicenseConcluded        string                   `json:"licenseConcluded"`
+	LicenseDeclared         string                   `json:"licenseDeclared"`
+	CopyrightText           string                   `json:"copyrightText"`
+	PackageVerificationCode *PackageVerificationCode `json:"packageVerificationCode,omitempty"`
+	Comment                 string                   `json:"comment,omitempty"`
+}
+
+// PackageVerificationCode is SPDX's integrity check for a Package. GenerateSBOM only
+// knows about whole-layer digests rather than the per-file SHA1s the spec describes,
+// so Value is a SHA-256 over the sorted layer digests backing the package instead --
+// close enough to catch "this package's content changed," not a spec-literal code.
+type PackageVerificationCode struct {
+	Value string `json:"packageVerificationCodeValue"`
+}
+
+// Relationship is one SPDX Relationship element, e.g. "SPDXRef-A CONTAINS SPDXRef-B".
+type Relationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+var spdxIDDisallowed = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// spdxID builds an SPDXRef- identifier out of parts, replacing anything outside the
+// SPDX-safe character set (e.g. path separators in a Kitfile path) with a dash.
+func spdxID(parts ...string) string {
+	joined := "SPDXRef-Package"
+	for _, p := range parts {
+		joined += "-" + spdxIDDisallowed.ReplaceAllString(p, "-")
+	}
+	return joined
+}
+
+// verificationCode hashes digests (already sorted by the caller) into a
+// PackageVerificationCode, or returns nil if there's nothing to hash.
+func verificationCode(digests []string) *PackageVerificationCode {
+	if len(digests) == 0 {
+		return nil
+	}
+	h := sha256.New()
+	for _, d := range digests {
+		h.Write([]byte(d))
+	}
+	return &PackageVerificationCode{Value: hex.EncodeToString(h.Sum(nil))}
+}
+
+// layerDigestsForPath returns the digests (sorted) of every layer in manifest whose
+// modelspecv1.AnnotationFilepath annotation matches path exactly. Pack writes this
+// annotation on every layer it produces, for both KitOps-native and ModelPack-format
+// manifests, so it's a reliable way to recover "which layer(s) back this Kitfile
+// section" without assuming anything about layer ordering.
+func layerDigestsForPath(manifest *ocispec.Manifest, path string) []string {
+	var digests []string
+	for _, layer := range manifest.Layers {
+		if layer.Annotations[modelspecv1.AnnotationFilepath] == path {
+			digests = append(digests, layer.Digest.String())
+		}
+	}
+	sort.Strings(digests)
+	return digests
+}
+
+// licenseOrDefault returns license, or noAssertion if it's empty.
+func licenseOrDefault(license string) string {
+	if license == "" {
+		return noAssertion
+	}
+	return license
+}
+
+// GenerateSBOM walks kitfile's package, model, model.parts, datasets, code, and docs
+// sections and produces an SPDX 2.3 document describing them: one Package per section,
+// a packageVerificationCode derived from the layer digests manifest already records for
+// that section's path, and DESCRIBES/CONTAINS/DEPENDS_ON relationships expressing the
+// model -> parts -> datasets graph. ref is the ModelKit reference the SBOM was
+// generated for (used as the root package's name and download location); manifestDigest
+// identifies the manifest the top-level DocumentDescribes element points at.
+func GenerateSBOM(ref string, manifestDigest digest.Digest, manifest *ocispec.Manifest, kitfile *artifact.KitFile, created time.Time) (*Document, error) {
+	if manifest == nil {
+		return nil, fmt.Errorf("cannot generate SBOM: manifest is nil")
+	}
+	if kitfile == nil {
+		return nil, fmt.Errorf("cannot generate SBOM: kitfile is nil")
+	}
+
+	// A local, unpushed ModelKit has no manifest digest (see loadLocalTarget).
+	shortDigest := "local"
+	if manifestDigest != "" {
+		shortDigest = manifestDigest.Encoded()[0:12]
+	}
+	rootID := spdxID("modelkit", shortDigest)
+	doc := &Document{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              ref,
+		DocumentNamespace: fmt.Sprintf("https://kitops.dev/spdx/%s-%s", ref, shortDigest),
+		CreationInfo: CreationInfo{
+			Created:  created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: kit-sbom"},
+		},
+		DocumentDescribes: []string{rootID},
+	}
+
+	rootPkg := Package{
+		SPDXID:           rootID,
+		Name:             ref,
+		DownloadLocation: ref,
+		FilesAnalyzed:    false,
+		LicenseConcluded: noAssertion,
+		LicenseDeclared:  licenseOrDefault(kitfile.Package.License),
+		CopyrightText:    noAssertion,
+		Comment:          fmt.Sprintf("ModelKit manifest digest: %s", manifestDigest),
+	}
+	doc.Packages = append(doc.Packages, rootPkg)
+	doc.Relationships = append(doc.Relationships, Relationship{
+		SPDXElementID:      "SPDXRef-DOCUMENT",
+		RelationshipType:   RelationshipDescribes,
+		RelatedSPDXElement: rootID,
+	})
+
+	if kitfile.Model != nil && kitfile.Model.Path != "" {
+		modelID := spdxID("model", kitfile.Model.Path)
+		name := kitfile.Model.Name
+		if name == "" {
+			name = "model"
+		}
+		doc.Packages = append(doc.Packages, Package{
+			SPDXID:                  modelID,
+			Name:                    name,
+			DownloadLocation:        noAssertion,
+			FilesAnalyzed:           true,
+			LicenseConcluded:        noAssertion,
+			LicenseDeclared:         licenseOrDefault(kitfile.Model.License),
+			CopyrightText:           noAssertion,
+			PackageVerificationCode: verificationCode(layerDigestsForPath(manifest, kitfile.Model.Path)),
+		})
+		doc.Relationships = append(doc.Relationships, Relationship{
+			SPDXElementID:      rootID,
+			RelationshipType:   RelationshipContains,
+			RelatedSPDXElement: modelID,
+		})
+
+		for i, part := range kitfile.Model.Parts {
+			if part.Path == "" {
+				continue
+			}
+			partID := spdxID("model-part", fmt.Sprintf("%d", i), part.Path)
+			partName := part.Name
+			if partName == "" {
+				partName = fmt.Sprintf("model-part-%d", i)
+			}
+			doc.Packages = append(doc.Packages, Package{
+				SPDXID:                  partID,
+				Name:                    partName,
+				DownloadLocation:        noAssertion,
+				FilesAnalyzed:           true,
+				LicenseConcluded:        noAssertion,
+				LicenseDeclared:         licenseOrDefault(part.License),
+				CopyrightText:           noAssertion,
+				PackageVerificationCode: verificationCode(layerDigestsForPath(manifest, part.Path)),
+			})
+			doc.Relationships = append(doc.Relationships, Relationship{
+				SPDXElementID:      modelID,
+				RelationshipType:   RelationshipContains,
+				RelatedSPDXElement: partID,
+			})
+		}
+	}
+
+	for i, ds := range kitfile.DataSets {
+		if ds.Path == "" {
+			continue
+		}
+		dsID := spdxID("dataset", fmt.Sprintf("%d", i), ds.Path)
+		dsName := ds.Name
+		if dsName == "" {
+			dsName = fmt.Sprintf("dataset-%d", i)
+		}
+		doc.Packages = append(doc.Packages, Package{
+			SPDXID:                  dsID,
+			Name:                    dsName,
+			DownloadLocation:        noAssertion,
+			FilesAnalyzed:           true,
+			LicenseConcluded:        noAssertion,
+			LicenseDeclared:         licenseOrDefault(ds.License),
+			CopyrightText:           noAssertion,
+			PackageVerificationCode: verificationCode(layerDigestsForPath(manifest, ds.Path)),
+		})
+		doc.Relationships = append(doc.Relationships, Relationship{
+			SPDXElementID:      rootID,
+			RelationshipType:   RelationshipContains,
+			RelatedSPDXElement: dsID,
+		})
+		if kitfile.Model != nil && kitfile.Model.Path != "" {
+			doc.Relationships = append(doc.Relationships, Relationship{
+				SPDXElementID:      spdxID("model", kitfile.Model.Path),
+				RelationshipType:   RelationshipDependsOn,
+				RelatedSPDXElement: dsID,
+			})
+		}
+	}
+
+	for i, c := range kitfile.Code {
+		if c.Path == "" {
+			continue
+		}
+		codeID := spdxID("code", fmt.Sprintf("%d", i), c.Path)
+		doc.Packages = append(doc.Packages, Package{
+			SPDXID:                  codeID,
+			Name:                    fmt.Sprintf("code-%d", i),
+			DownloadLocation:        noAssertion,
+			FilesAnalyzed:           true,
+			LicenseConcluded:        noAssertion,
+			LicenseDeclared:         licenseOrDefault(c.License),
+			CopyrightText:           noAssertion,
+			PackageVerificationCode: verificationCode(layerDigestsForPath(manifest, c.Path)),
+		})
+		doc.Relationships = append(doc.Relationships, Relationship{
+			SPDXElementID:      rootID,
+			RelationshipType:   RelationshipContains,
+			RelatedSPDXElement: codeID,
+		})
+	}
+
+	for i, d := range kitfile.Docs {
+		if d.Path == "" {
+			continue
+		}
+		docsID := spdxID("docs", fmt.Sprintf("%d", i), d.Path)
+		doc.Packages = append(doc.Packages, Package{
+			SPDXID:                  docsID,
+			Name:                    fmt.Sprintf("docs-%d", i),
+			DownloadLocation:        noAssertion,
+			FilesAnalyzed:           true,
+			LicenseConcluded:        noAssertion,
+			LicenseDeclared:         noAssertion,
+			CopyrightText:           noAssertion,
+			PackageVerificationCode: verificationCode(layerDigestsForPath(manifest, d.Path)),
+		})
+		doc.Relationships = append(doc.Relationships, Relationship{
+			SPDXElementID:      rootID,
+			RelationshipType:   RelationshipContains,
+			RelatedSPDXElement: docsID,
+		})
+	}
+
+	return doc, nil
+}
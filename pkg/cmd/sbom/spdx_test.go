@@ -0,0 +1,110 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+	modelspecv1 "github.com/modelpack/model-spec/specs-go/v1"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func layerFor(path string) ocispec.Descriptor {
+	return ocispec.Descriptor{
+		Digest:      digest.FromString(path),
+		Size:        1,
+		Annotations: map[string]string{modelspecv1.AnnotationFilepath: path},
+	}
+}
+
+func TestGenerateSBOMDescribesEveryKitfileSection(t *testing.T) {
+	manifest := &ocispec.Manifest{
+		Layers: []ocispec.Descriptor{
+			layerFor("model-files"),
+			layerFor("dataset"),
+			layerFor("code"),
+		},
+	}
+	kitfile := &artifact.KitFile{
+		Package: artifact.Package{Name: "test-package", License: "Apache-2.0"},
+		Model:   &artifact.Model{Path: "model-files", Name: "test-model", License: "MIT"},
+		DataSets: []artifact.DataSet{
+			{Path: "dataset", Name: "test-dataset", License: "CC-BY-4.0"},
+		},
+		Code: []artifact.Code{
+			{Path: "code", License: "Apache-2.0"},
+		},
+	}
+
+	doc, err := GenerateSBOM("registry.example.com/models/test:latest", digest.FromString("manifest"), manifest, kitfile, time.Unix(0, 0))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	assert.Len(t, doc.DocumentDescribes, 1)
+	// Root + model + dataset + code == 4 packages
+	assert.Len(t, doc.Packages, 4)
+
+	var modelPkg, dsPkg *Package
+	for i := range doc.Packages {
+		switch doc.Packages[i].Name {
+		case "test-model":
+			modelPkg = &doc.Packages[i]
+		case "test-dataset":
+			dsPkg = &doc.Packages[i]
+		}
+	}
+	if !assert.NotNil(t, modelPkg) || !assert.NotNil(t, dsPkg) {
+		return
+	}
+	assert.Equal(t, "MIT", modelPkg.LicenseDeclared)
+	assert.NotNil(t, modelPkg.PackageVerificationCode)
+	assert.NotEmpty(t, modelPkg.PackageVerificationCode.Value)
+
+	assert.Contains(t, doc.Relationships, Relationship{
+		SPDXElementID:      modelPkg.SPDXID,
+		RelationshipType:   RelationshipDependsOn,
+		RelatedSPDXElement: dsPkg.SPDXID,
+	})
+}
+
+func TestGenerateSBOMMissingLicenseFallsBackToNoAssertion(t *testing.T) {
+	manifest := &ocispec.Manifest{}
+	kitfile := &artifact.KitFile{
+		Package: artifact.Package{Name: "unlicensed"},
+	}
+
+	doc, err := GenerateSBOM("unlicensed:latest", digest.FromString("manifest"), manifest, kitfile, time.Unix(0, 0))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, noAssertion, doc.Packages[0].LicenseDeclared)
+	assert.Nil(t, doc.Packages[0].PackageVerificationCode)
+}
+
+func TestGenerateSBOMRequiresManifestAndKitfile(t *testing.T) {
+	_, err := GenerateSBOM("ref", digest.FromString("x"), nil, &artifact.KitFile{}, time.Unix(0, 0))
+	assert.ErrorContains(t, err, "manifest")
+
+	_, err = GenerateSBOM("ref", digest.FromString("x"), &ocispec.Manifest{}, nil, time.Unix(0, 0))
+	assert.ErrorContains(t, err, "kitfile")
+}
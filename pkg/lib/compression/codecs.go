@@ -0,0 +1,99 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func init() {
+	Register(noneCodec{})
+	Register(gzipCodec{name: "gzip", level: gzip.DefaultCompression})
+	Register(gzipCodec{name: "gzip-fastest", level: gzip.BestSpeed})
+	Register(zstdCodec{})
+}
+
+// noneCodec passes data through unmodified.
+type noneCodec struct{}
+
+func (noneCodec) Name() string            { return "none" }
+func (noneCodec) MediaTypeSuffix() string { return "" }
+
+func (noneCodec) Compress(w io.Writer, _ Options) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+func (noneCodec) Decompress(r io.Reader) (io.ReadCloser, error) {
+	return io.NopCloser(r), nil
+}
+
+// gzipCodec wraps compress/gzip. "gzip" and "gzip-fastest" are both registered as
+// instances of this type, differing only in compression level; they share the same
+// "gzip" media type suffix since the wire format is identical.
+type gzipCodec struct {
+	name  string
+	level int
+}
+
+func (c gzipCodec) Name() string          { return c.name }
+func (gzipCodec) MediaTypeSuffix() string { return "gzip" }
+
+func (c gzipCodec) Compress(w io.Writer, _ Options) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, c.level)
+}
+
+func (gzipCodec) Decompress(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// zstdCodec wraps github.com/klauspost/compress/zstd, a stable pure-Go implementation.
+type zstdCodec struct{}
+
+func (zstdCodec) Name() string            { return "zstd" }
+func (zstdCodec) MediaTypeSuffix() string { return "zstd" }
+
+func (zstdCodec) Compress(w io.Writer, _ Options) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
+
+func (zstdCodec) Decompress(r io.Reader) (io.ReadCloser, error) {
+	dec, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return zstdDecoderCloser{dec}, nil
+}
+
+// zstdDecoderCloser adapts *zstd.Decoder, whose Close method doesn't return an error,
+// to io.ReadCloser.
+type zstdDecoderCloser struct {
+	*zstd.Decoder
+}
+
+func (z zstdDecoderCloser) Close() error {
+	z.Decoder.Close()
+	return nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,75 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package compression defines a pluggable interface for the codecs used to compress
+// ModelKit layers, so that adding a new one is a single Register call rather than a
+// change to every pack/unpack/mediatype switch statement that cared about compression.
+package compression
+
+import (
+	"io"
+	"sort"
+)
+
+// Codec implements a single compression algorithm, identified by a canonical Name used
+// in the --compression flag and ParseCompression, and by the media type suffix it's
+// written under (e.g. "gzip" for "...v1.tar+gzip"). Codecs register themselves via
+// Register, typically from an init() in the file that implements them.
+type Codec interface {
+	// Name is the canonical name used by ParseCompression and the --compression flag.
+	Name() string
+	// MediaTypeSuffix is the "+<suffix>" appended to a tar media type for this codec,
+	// or "" if the codec writes no suffix (e.g. NoneCompression).
+	MediaTypeSuffix() string
+	// Compress wraps w so that writes to the returned WriteCloser are compressed.
+	// Closing the returned WriteCloser must flush and close the underlying stream.
+	Compress(w io.Writer, opts Options) (io.WriteCloser, error)
+	// Decompress wraps r so that reads from the returned ReadCloser are decompressed.
+	Decompress(r io.Reader) (io.ReadCloser, error)
+}
+
+// Options carries codec-specific tuning knobs. Codecs ignore fields that don't apply
+// to them.
+type Options struct {
+	// Fastest requests the codec's fastest, lowest-ratio compression level, if it has one.
+	Fastest bool
+}
+
+var registry = map[string]Codec{}
+
+// Register adds codec to the registry under its Name(), so ParseCompression and the
+// --compression flag can look it up without a hard-coded switch. Registering a name
+// twice overwrites the previous codec.
+func Register(codec Codec) {
+	registry[codec.Name()] = codec
+}
+
+// Lookup returns the codec registered under name, if any.
+func Lookup(name string) (Codec, bool) {
+	codec, ok := registry[name]
+	return codec, ok
+}
+
+// Names returns the names of all registered codecs in sorted order, for building
+// usage/help text and validation error messages.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
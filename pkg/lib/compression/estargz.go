@@ -0,0 +1,343 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package compression
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+func init() {
+	Register(estargzCodec{})
+}
+
+// estargzCodec writes an eStargz-style seekable layer: the same tar stream a plain
+// "gzip" codec would produce, but with every file re-framed as its own, independently
+// decompressable gzip member, followed by a table of contents (TOC) listing each
+// file's byte range. A sequential reader - `kit unpack` today, via Decompress - can't
+// tell the difference from plain gzip: compress/gzip transparently concatenates
+// consecutive members, and archive/tar stops reading at the end-of-archive marker
+// before it ever reaches the TOC or footer. A range-fetch client that has the TOC can
+// instead download and verify only the members it needs; see ReadTOC and EntryRange.
+type estargzCodec struct{}
+
+func (estargzCodec) Name() string            { return "estargz" }
+func (estargzCodec) MediaTypeSuffix() string { return "estargz" }
+
+func (c estargzCodec) Compress(w io.Writer, opts Options) (io.WriteCloser, error) {
+	pr, pw := io.Pipe()
+	errCh := make(chan error, 1)
+	go func() {
+		err := writeEstargz(pr, w, gzipLevel(opts))
+		pr.CloseWithError(err)
+		errCh <- err
+	}()
+	return &estargzWriter{pw: pw, errCh: errCh}, nil
+}
+
+func (estargzCodec) Decompress(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+func gzipLevel(opts Options) int {
+	if opts.Fastest {
+		return gzip.BestSpeed
+	}
+	return gzip.DefaultCompression
+}
+
+// estargzWriter feeds bytes written to it into writeEstargz through a pipe, so Compress
+// can expose the generic Codec.Compress(io.Writer) shape while writeEstargz gets to read
+// its input as a tar stream via archive/tar.
+type estargzWriter struct {
+	pw    *io.PipeWriter
+	errCh chan error
+}
+
+func (ew *estargzWriter) Write(p []byte) (int, error) {
+	return ew.pw.Write(p)
+}
+
+func (ew *estargzWriter) Close() error {
+	if err := ew.pw.Close(); err != nil {
+		return err
+	}
+	return <-ew.errCh
+}
+
+// countWriter tracks the number of bytes written through it so far, giving writeEstargz
+// each gzip member's starting offset in the output blob.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// writeEstargz reads the tar stream in r entry by entry, re-emitting each entry as its
+// own gzip member in w, then appends a terminating member and a gzip-compressed TOC
+// listing every entry's byte range and content digest.
+func writeEstargz(r io.Reader, w io.Writer, level int) error {
+	cw := &countWriter{w: w}
+	tr := tar.NewReader(r)
+	toc := &TOC{Version: 1}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %w", err)
+		}
+
+		start := cw.n
+		entry, err := writeEstargzEntry(cw, level, hdr, tr)
+		if err != nil {
+			return err
+		}
+		entry.Offset = start
+		entry.CompressedSize = cw.n - start
+		toc.Entries = append(toc.Entries, entry)
+	}
+
+	if err := writeEstargzTerminator(cw, level); err != nil {
+		return err
+	}
+
+	tocOffset := cw.n
+	tocDigest, err := writeEstargzTOC(cw, level, toc)
+	if err != nil {
+		return err
+	}
+	toc.Digest = tocDigest
+	tocSize := cw.n - tocOffset
+
+	return writeFooter(w, tocOffset, tocSize)
+}
+
+// writeEstargzEntry gzip-compresses a single tar entry (header and content) as its own
+// gzip member, returning the TOCEntry describing it except for Offset/CompressedSize,
+// which the caller fills in since it's tracking cw across entries.
+func writeEstargzEntry(cw *countWriter, level int, hdr *tar.Header, content io.Reader) (TOCEntry, error) {
+	gz, err := gzip.NewWriterLevel(cw, level)
+	if err != nil {
+		return TOCEntry{}, fmt.Errorf("failed to start gzip member for %s: %w", hdr.Name, err)
+	}
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(hdr); err != nil {
+		return TOCEntry{}, fmt.Errorf("failed to write tar header for %s: %w", hdr.Name, err)
+	}
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tw, hasher), content)
+	if err != nil {
+		return TOCEntry{}, fmt.Errorf("failed to write content for %s: %w", hdr.Name, err)
+	}
+	// Flush pads this entry to a tar block boundary without writing the end-of-archive
+	// marker, which is written once, after the last entry, by writeEstargzTerminator.
+	if err := tw.Flush(); err != nil {
+		return TOCEntry{}, fmt.Errorf("failed to flush tar entry for %s: %w", hdr.Name, err)
+	}
+	if err := gz.Close(); err != nil {
+		return TOCEntry{}, fmt.Errorf("failed to close gzip member for %s: %w", hdr.Name, err)
+	}
+
+	return TOCEntry{
+		Name:             hdr.Name,
+		UncompressedSize: size,
+		Digest:           "sha256:" + hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
+
+// writeEstargzTerminator appends a gzip member containing only the two zero-filled
+// blocks archive/tar uses to mark the end of an archive, so the tar stream formed by
+// concatenating every entry's gzip member (which a sequential reader decompresses
+// transparently) is itself a valid, complete tar archive.
+func writeEstargzTerminator(cw *countWriter, level int) error {
+	gz, err := gzip.NewWriterLevel(cw, level)
+	if err != nil {
+		return fmt.Errorf("failed to start terminator gzip member: %w", err)
+	}
+	if err := tar.NewWriter(gz).Close(); err != nil {
+		return fmt.Errorf("failed to write tar terminator: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to close terminator gzip member: %w", err)
+	}
+	return nil
+}
+
+// writeEstargzTOC gzip-compresses toc's JSON encoding as the final member of the blob,
+// returning its digest for the caller to record in the footer-adjacent TOC itself.
+func writeEstargzTOC(cw *countWriter, level int, toc *TOC) (string, error) {
+	data, err := json.Marshal(toc)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal estargz TOC: %w", err)
+	}
+	gz, err := gzip.NewWriterLevel(cw, level)
+	if err != nil {
+		return "", fmt.Errorf("failed to start TOC gzip member: %w", err)
+	}
+	if _, err := gz.Write(data); err != nil {
+		return "", fmt.Errorf("failed to write estargz TOC: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("failed to close TOC gzip member: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// TOCEntry describes one file packed into an eStargz layer: the byte range, within the
+// compressed blob, of that file's self-contained gzip member, plus enough information
+// to verify it once fetched and decompressed independently of the rest of the layer.
+type TOCEntry struct {
+	Name             string `json:"name"`
+	Offset           int64  `json:"offset"`
+	CompressedSize   int64  `json:"compressedSize"`
+	UncompressedSize int64  `json:"uncompressedSize"`
+	// Digest is the sha256 digest of the entry's uncompressed file content (not
+	// including the tar header), in "sha256:<hex>" form.
+	Digest string `json:"digest"`
+}
+
+// TOC is the table of contents appended to an eStargz layer. It is itself stored as a
+// gzip-compressed JSON member; ReadTOC locates and decodes it via the blob's footer.
+type TOC struct {
+	Version int        `json:"version"`
+	Entries []TOCEntry `json:"entries"`
+	// Digest is the sha256 digest of the TOC's own JSON encoding, set once the TOC has
+	// been written; it is not included in its own encoding.
+	Digest string `json:"-"`
+}
+
+// EntryRange returns the inclusive byte range, within the compressed blob, of name's
+// gzip member - suitable for an HTTP "Range: bytes=start-end" request - so a caller can
+// fetch and decompress exactly one file without downloading the rest of the layer. ok
+// is false if name isn't tracked in the TOC.
+func (t *TOC) EntryRange(name string) (start, end int64, ok bool) {
+	for _, e := range t.Entries {
+		if e.Name == name {
+			return e.Offset, e.Offset + e.CompressedSize - 1, true
+		}
+	}
+	return 0, 0, false
+}
+
+// footerSize is the fixed size, in bytes, of the trailer writeFooter appends to every
+// eStargz blob: an 8-byte magic number, the 8-byte offset and 8-byte size of the TOC's
+// gzip member, and 8 reserved bytes. Unlike every other member in the blob, the footer
+// is not itself gzip-compressed: archive/tar stops reading at the end-of-archive marker
+// written by writeEstargzTerminator and never asks for these trailing bytes, so they
+// don't need to be valid gzip for sequential (non-range-fetch) reads to keep working.
+const footerSize = 32
+
+const footerMagic uint64 = 0x6b69746f70737467 // ASCII "kitopstg"
+
+// writeFooter appends the fixed-size eStargz footer to w, recording where the TOC's
+// gzip member starts and how long it is.
+func writeFooter(w io.Writer, tocOffset, tocSize int64) error {
+	var buf [footerSize]byte
+	binary.BigEndian.PutUint64(buf[0:8], footerMagic)
+	binary.BigEndian.PutUint64(buf[8:16], uint64(tocOffset))
+	binary.BigEndian.PutUint64(buf[16:24], uint64(tocSize))
+	_, err := w.Write(buf[:])
+	return err
+}
+
+// ReadFooter reads the fixed-size footer from the end of an eStargz blob of the given
+// total size, returning the offset and size of its TOC gzip member.
+func ReadFooter(ra io.ReaderAt, blobSize int64) (tocOffset, tocSize int64, err error) {
+	if blobSize < footerSize {
+		return 0, 0, fmt.Errorf("blob of size %d is too small to contain an estargz footer", blobSize)
+	}
+	var buf [footerSize]byte
+	if _, err := ra.ReadAt(buf[:], blobSize-footerSize); err != nil {
+		return 0, 0, fmt.Errorf("failed to read estargz footer: %w", err)
+	}
+	if magic := binary.BigEndian.Uint64(buf[0:8]); magic != footerMagic {
+		return 0, 0, fmt.Errorf("blob does not end with an estargz footer")
+	}
+	tocOffset = int64(binary.BigEndian.Uint64(buf[8:16]))
+	tocSize = int64(binary.BigEndian.Uint64(buf[16:24]))
+	return tocOffset, tocSize, nil
+}
+
+// ReadTOC fetches and parses the TOC for an eStargz blob, given random access to the
+// full compressed blob and its total size. A registry-backed range-fetch client instead
+// issues two HTTP Range requests - one for the footer, one for the TOC it points to -
+// rather than calling this directly against the whole blob.
+func ReadTOC(ra io.ReaderAt, blobSize int64) (*TOC, error) {
+	tocOffset, tocSize, err := ReadFooter(ra, blobSize)
+	if err != nil {
+		return nil, err
+	}
+	section := io.NewSectionReader(ra, tocOffset, tocSize)
+	gz, err := gzip.NewReader(section)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress estargz TOC: %w", err)
+	}
+	defer gz.Close()
+
+	data, err := io.ReadAll(gz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read estargz TOC: %w", err)
+	}
+	var toc TOC
+	if err := json.Unmarshal(data, &toc); err != nil {
+		return nil, fmt.Errorf("failed to parse estargz TOC: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	toc.Digest = "sha256:" + hex.EncodeToString(sum[:])
+	return &toc, nil
+}
+
+// VerifyChunk decompresses a single file's gzip member, as fetched via EntryRange, and
+// confirms its content matches the digest recorded for it in the TOC, so a range-fetch
+// client can't be served truncated or corrupted data for the one file it asked for.
+func VerifyChunk(entry TOCEntry, chunk []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(chunk))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress chunk for %s: %w", entry.Name, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	if _, err := tr.Next(); err != nil {
+		return nil, fmt.Errorf("failed to read tar header for %s: %w", entry.Name, err)
+	}
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content for %s: %w", entry.Name, err)
+	}
+	sum := sha256.Sum256(data)
+	if got := "sha256:" + hex.EncodeToString(sum[:]); got != entry.Digest {
+		return nil, fmt.Errorf("chunk digest mismatch for %s: expected %s, got %s", entry.Name, entry.Digest, got)
+	}
+	return data, nil
+}
@@ -40,6 +40,10 @@ const (
 	HarnessSubpath      = "harness"
 	HarnessProcessFile  = "process.pid"
 	HarnessLogFile      = "harness.log"
+	// UploadsSubpath holds persisted state for in-progress resumable blob uploads, so
+	// a `kit push` killed mid-upload can continue it on the next run instead of
+	// restarting from byte zero. See pkg/cache/uploads.
+	UploadsSubpath = "uploads"
 
 	// Kitops-specific annotations for modelkit artifacts
 	CliVersionAnnotation = "ml.kitops.modelkit.cli-version"
@@ -0,0 +1,75 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import (
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ArtifactManifestMediaType is the OCI artifact-manifest media type introduced in
+// image-spec v1.1.0-rc1 and removed again in rc3, when the spec consolidated on
+// reusing the image manifest (with ArtifactType set, see ArtifactTypeKitManifest)
+// for artifacts instead. Some early KitOps tooling, and some third-party pushers,
+// still produce this shape; ArtifactManifest, ModelFormatForArtifact, and
+// ManifestFromArtifact exist to keep reading it working.
+const ArtifactManifestMediaType = "application/vnd.oci.artifact.manifest.v1+json"
+
+// ArtifactManifest mirrors the OCI artifact-manifest body from image-spec
+// v1.1.0-rc1/rc2, which the image-spec version this tree depends on no longer
+// defines: blobs in place of an image manifest's Config+Layers, and no
+// descriptor-level Config at all.
+type ArtifactManifest struct {
+	MediaType    string               `json:"mediaType"`
+	ArtifactType string               `json:"artifactType"`
+	Blobs        []ocispec.Descriptor `json:"blobs,omitempty"`
+	Subject      *ocispec.Descriptor  `json:"subject,omitempty"`
+	Annotations  map[string]string    `json:"annotations,omitempty"`
+}
+
+// ModelFormatForArtifact determines whether manifest is a KitOps-native or CNCF
+// ModelPack ModelKit, mirroring ModelFormatForManifest. An artifact manifest has no
+// config descriptor to fall back on the way ModelFormatForManifest does for
+// pre-ArtifactType ModelKits, so ArtifactType is its sole discriminator.
+func ModelFormatForArtifact(manifest *ArtifactManifest) (ModelFormat, error) {
+	switch manifest.ArtifactType {
+	case ArtifactTypeKitManifest:
+		return KitFormat, nil
+	case ArtifactTypeModelManifest:
+		return ModelPackFormat, nil
+	}
+	return UnknownModelFormat, fmt.Errorf("artifact manifest is not a Model manifest: artifactType is %s", manifest.ArtifactType)
+}
+
+// ManifestFromArtifact lifts manifest into the image-manifest+empty-config shape
+// ModelFormatForManifest and the rest of this tree already understand, so the
+// deprecated artifact-manifest shape only has to be translated once, on pull,
+// rather than threading a second manifest shape through every downstream caller.
+// The result is exactly what packManifestV1_1 (see pack.PackModelKitManifest)
+// would have produced: the OCI empty-config sentinel standing in for Config, and
+// Blobs renamed to Layers since an artifact manifest's blobs play the same role.
+func ManifestFromArtifact(manifest *ArtifactManifest) *ocispec.Manifest {
+	return &ocispec.Manifest{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: manifest.ArtifactType,
+		Config:       ocispec.DescriptorEmptyJSON,
+		Layers:       manifest.Blobs,
+		Subject:      manifest.Subject,
+		Annotations:  manifest.Annotations,
+	}
+}
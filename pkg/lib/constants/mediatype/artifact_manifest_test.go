@@ -0,0 +1,62 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestModelFormatForArtifact(t *testing.T) {
+	format, err := ModelFormatForArtifact(&ArtifactManifest{ArtifactType: ArtifactTypeKitManifest})
+	if assert.NoError(t, err) {
+		assert.Equal(t, KitFormat, format)
+	}
+
+	format, err = ModelFormatForArtifact(&ArtifactManifest{ArtifactType: ArtifactTypeModelManifest})
+	if assert.NoError(t, err) {
+		assert.Equal(t, ModelPackFormat, format)
+	}
+
+	_, err = ModelFormatForArtifact(&ArtifactManifest{ArtifactType: "application/vnd.example.other.v1+json"})
+	assert.Error(t, err, "an artifact manifest has no config to fall back on")
+}
+
+func TestManifestFromArtifact(t *testing.T) {
+	blobs := []ocispec.Descriptor{{Digest: "sha256:layer"}}
+	artifact := &ArtifactManifest{
+		MediaType:    ArtifactManifestMediaType,
+		ArtifactType: ArtifactTypeKitManifest,
+		Blobs:        blobs,
+		Annotations:  map[string]string{"foo": "bar"},
+	}
+
+	manifest := ManifestFromArtifact(artifact)
+	assert.Equal(t, ocispec.MediaTypeImageManifest, manifest.MediaType)
+	assert.Equal(t, ArtifactTypeKitManifest, manifest.ArtifactType)
+	assert.True(t, IsEmptyConfig(manifest.Config))
+	assert.Equal(t, blobs, manifest.Layers)
+	assert.Equal(t, artifact.Annotations, manifest.Annotations)
+
+	format, err := ModelFormatForManifest(manifest)
+	if assert.NoError(t, err) {
+		assert.Equal(t, KitFormat, format, "the translated manifest resolves through ModelFormatForManifest like any other ArtifactType-discriminated manifest")
+	}
+}
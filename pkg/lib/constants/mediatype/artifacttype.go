@@ -27,11 +27,47 @@ const (
 	ArtifactTypeModelManifest = "application/vnd.cncf.model.manifest.v1+json"
 )
 
+// IsEmptyConfig reports whether desc is the OCI image-spec v1.1 empty-config sentinel
+// (media type application/vnd.oci.empty.v1+json, the fixed two-byte "{}" body) that
+// artifact-only manifests use in place of a real config blob. It checks media type,
+// digest, and size together so a descriptor that only reuses the media type string
+// isn't mistaken for a genuine empty config.
+func IsEmptyConfig(desc ocispec.Descriptor) bool {
+	return desc.MediaType == ocispec.DescriptorEmptyJSON.MediaType &&
+		desc.Digest == ocispec.DescriptorEmptyJSON.Digest &&
+		desc.Size == ocispec.DescriptorEmptyJSON.Size
+}
+
+// ModelFormatForManifest determines whether manifest is a KitOps-native or CNCF
+// ModelPack ModelKit. Two generations of manifest shape have to be reconciled here:
+// pre-1.1 artifacts never set ArtifactType at all and can only be identified by
+// Config.MediaType, while OCI image-spec v1.1 artifacts may use the empty-config
+// sentinel and rely on ArtifactType as the sole discriminator. configMediaType is
+// cleared for an empty config so the ArtifactType checks below take precedence instead
+// of matching on a config media type that doesn't actually say anything about the
+// artifact. A manifest translated from the deprecated ArtifactManifestMediaType shape
+// via ManifestFromArtifact resolves through this same ArtifactType path, since
+// ModelFormatForArtifact already requires ArtifactType to be set before translation.
 func ModelFormatForManifest(manifest *ocispec.Manifest) (ModelFormat, error) {
-	if manifest.ArtifactType == ArtifactTypeKitManifest || manifest.Config.MediaType == KitConfigMediaType.String() {
+	artifactType := manifest.ArtifactType
+	configMediaType := manifest.Config.MediaType
+	if IsEmptyConfig(manifest.Config) {
+		configMediaType = ""
+	}
+
+	if artifactType == "" {
+		switch configMediaType {
+		case KitConfigMediaType.String():
+			return KitFormat, nil
+		case ModelPackConfigMediaType.String():
+			return ModelPackFormat, nil
+		}
+	}
+
+	if artifactType == ArtifactTypeKitManifest || configMediaType == KitConfigMediaType.String() {
 		return KitFormat, nil
 	}
-	if manifest.ArtifactType == ArtifactTypeModelManifest || manifest.Config.MediaType == ModelPackConfigMediaType.String() {
+	if artifactType == ArtifactTypeModelManifest || configMediaType == ModelPackConfigMediaType.String() {
 		return ModelPackFormat, nil
 	}
 	return UnknownModelFormat, fmt.Errorf("manifest is not a Model manifest: artifactType is %s, config mediaType is %s", manifest.ArtifactType, manifest.Config.MediaType)
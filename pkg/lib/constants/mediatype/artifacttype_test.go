@@ -0,0 +1,82 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestIsEmptyConfig(t *testing.T) {
+	assert.True(t, IsEmptyConfig(ocispec.DescriptorEmptyJSON))
+	assert.False(t, IsEmptyConfig(ocispec.Descriptor{
+		MediaType: ocispec.DescriptorEmptyJSON.MediaType,
+		Digest:    "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		Size:      2,
+	}), "a descriptor that only reuses the empty-config media type string should not count")
+	assert.False(t, IsEmptyConfig(ocispec.Descriptor{MediaType: "application/vnd.kitops.modelkit.config.v1+json"}))
+}
+
+func TestModelFormatForManifestEmptyConfigUsesArtifactType(t *testing.T) {
+	manifest := &ocispec.Manifest{
+		ArtifactType: ArtifactTypeKitManifest,
+		Config:       ocispec.DescriptorEmptyJSON,
+	}
+	format, err := ModelFormatForManifest(manifest)
+	if assert.NoError(t, err) {
+		assert.Equal(t, KitFormat, format)
+	}
+
+	manifest = &ocispec.Manifest{
+		ArtifactType: ArtifactTypeModelManifest,
+		Config:       ocispec.DescriptorEmptyJSON,
+	}
+	format, err = ModelFormatForManifest(manifest)
+	if assert.NoError(t, err) {
+		assert.Equal(t, ModelPackFormat, format)
+	}
+}
+
+func TestModelFormatForManifestEmptyConfigUnknownArtifactType(t *testing.T) {
+	manifest := &ocispec.Manifest{
+		ArtifactType: "application/vnd.example.other.v1+json",
+		Config:       ocispec.DescriptorEmptyJSON,
+	}
+	_, err := ModelFormatForManifest(manifest)
+	assert.Error(t, err, "an empty config gives ArtifactType no fallback to the config's media type")
+}
+
+func TestModelFormatForManifestLegacyConfigMediaType(t *testing.T) {
+	manifest := &ocispec.Manifest{
+		Config: ocispec.Descriptor{MediaType: KitConfigMediaType.String()},
+	}
+	format, err := ModelFormatForManifest(manifest)
+	if assert.NoError(t, err) {
+		assert.Equal(t, KitFormat, format)
+	}
+}
+
+func TestModelFormatForManifestUnknown(t *testing.T) {
+	manifest := &ocispec.Manifest{
+		Config: ocispec.Descriptor{MediaType: "application/vnd.example.unknown.v1+json"},
+	}
+	_, err := ModelFormatForManifest(manifest)
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import "strings"
+
+// EncryptedSuffix is appended to an already-compressed media type to mark a layer as
+// encrypted per the OCI image encryption spec, e.g. "...v1.tar+gzip+encrypted". It
+// composes with (rather than replaces) the layer's CompressionType, since an encrypted
+// layer's plaintext is itself a compressed tar: decrypt, then decompress, then untar.
+const EncryptedSuffix = "+encrypted"
+
+// encryptedMediaType wraps another MediaType to additionally mark it as encrypted. It
+// embeds the inner MediaType so Base/Compression/Format are inherited unchanged; only
+// the string forms grow the encrypted suffix.
+type encryptedMediaType struct {
+	MediaType
+}
+
+func (e *encryptedMediaType) String() string {
+	return e.MediaType.String() + EncryptedSuffix
+}
+
+func (e *encryptedMediaType) UserString() string {
+	return e.MediaType.UserString() + " (encrypted)"
+}
+
+var _ MediaType = (*encryptedMediaType)(nil)
+
+// StripEncryption reports whether s carries EncryptedSuffix, returning the underlying
+// media type string with the suffix removed so it can be parsed normally.
+func StripEncryption(s string) (inner string, encrypted bool) {
+	if strings.HasSuffix(s, EncryptedSuffix) {
+		return strings.TrimSuffix(s, EncryptedSuffix), true
+	}
+	return s, false
+}
+
+// IsEncryptedMediaTypeString reports whether a raw media type string carries the
+// encrypted suffix, without fully parsing it.
+func IsEncryptedMediaTypeString(s string) bool {
+	_, encrypted := StripEncryption(s)
+	return encrypted
+}
+
+// IsEncrypted reports whether mt was parsed from an encrypted media type string.
+func IsEncrypted(mt MediaType) bool {
+	_, ok := mt.(*encryptedMediaType)
+	return ok
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEncryptedMediaType(t *testing.T) {
+	mt, err := ParseMediaType("application/vnd.kitops.modelkit.dataset.v1.tar+gzip+encrypted")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, IsEncrypted(mt))
+	assert.Equal(t, GzipCompression, mt.Compression())
+	assert.Equal(t, DatasetBaseType, mt.Base())
+	assert.Equal(t, "application/vnd.kitops.modelkit.dataset.v1.tar+gzip+encrypted", mt.String())
+}
+
+func TestParseUnencryptedMediaType(t *testing.T) {
+	mt, err := ParseMediaType("application/vnd.kitops.modelkit.dataset.v1.tar+gzip")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, IsEncrypted(mt))
+}
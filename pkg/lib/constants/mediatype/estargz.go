@@ -0,0 +1,23 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+// EstargzTOCDigestAnnotation records the digest of an EstargzCompression layer's table
+// of contents, so a range-fetch client can fetch and verify just the TOC (via
+// compression.ReadTOC) before deciding which further byte ranges it actually needs,
+// without re-downloading or re-hashing the whole layer.
+const EstargzTOCDigestAnnotation = "ml.kitops.modelkit.estargz.toc-digest"
@@ -0,0 +1,122 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import (
+	"fmt"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// VariantAnnotation is a KitOps-specific annotation carried on a manifest descriptor
+// inside an OCI image index, identifying the quantization/runtime variant (e.g.
+// "fp16", "int8", "int4-awq") that descriptor's manifest provides. It lets a single
+// tag reference a family of modelkit variants without exploding the tag namespace.
+const VariantAnnotation = "ml.kitops.modelkit.variant"
+
+// ArtifactTypeKitIndex is the ArtifactType an OCI image index sets to identify itself
+// as a multi-platform/multi-variant ModelKit (as opposed to an index produced by some
+// other, unrelated OCI artifact tooling).
+const ArtifactTypeKitIndex = "application/vnd.kitops.modelkit.index.v1+json"
+
+// IsImageIndex returns true if mediaType identifies an OCI image index (a manifest
+// list), as opposed to a single image manifest.
+func IsImageIndex(mediaType string) bool {
+	return mediaType == ocispec.MediaTypeImageIndex
+}
+
+// SelectVariant returns the first descriptor in manifests whose VariantAnnotation
+// matches variant, for resolving a multi-variant OCI image index down to a single
+// child manifest (e.g. `kit pull foo:latest --variant int4-awq`).
+func SelectVariant(manifests []ocispec.Descriptor, variant string) (ocispec.Descriptor, bool) {
+	for _, desc := range manifests {
+		if desc.Annotations[VariantAnnotation] == variant {
+			return desc, true
+		}
+	}
+	return ocispec.Descriptor{}, false
+}
+
+// SelectPlatform returns the first descriptor in manifests whose Platform matches
+// want on OS, Architecture, and Variant (when want.Variant is set), for resolving a
+// multi-variant OCI image index down to a single child manifest (e.g.
+// `kit pull foo:latest --platform linux/amd64/cuda`).
+func SelectPlatform(manifests []ocispec.Descriptor, want *ocispec.Platform) (ocispec.Descriptor, bool) {
+	if want == nil {
+		return ocispec.Descriptor{}, false
+	}
+	for _, desc := range manifests {
+		have := desc.Platform
+		if have == nil || have.OS != want.OS || have.Architecture != want.Architecture {
+			continue
+		}
+		if want.Variant != "" && have.Variant != want.Variant {
+			continue
+		}
+		return desc, true
+	}
+	return ocispec.Descriptor{}, false
+}
+
+// ModelFormatForIndex determines whether index is a KitOps-native or CNCF ModelPack
+// multi-manifest ModelKit, mirroring ModelFormatForManifest. An index's own
+// ArtifactType decides when it is ArtifactTypeKitIndex or ArtifactTypeModelManifest;
+// any other value, including the empty one older indexes that predate
+// ArtifactTypeKitIndex carry, falls back to checking the family of the
+// first child manifest descriptor instead, on the assumption that an index never mixes
+// KitOps-native and ModelPack children.
+func ModelFormatForIndex(index *ocispec.Index) (ModelFormat, error) {
+	switch index.ArtifactType {
+	case ArtifactTypeKitIndex:
+		return KitFormat, nil
+	case ArtifactTypeModelManifest:
+		return ModelPackFormat, nil
+	}
+	if len(index.Manifests) == 0 {
+		return UnknownModelFormat, fmt.Errorf("index has no manifests: artifactType is %s", index.ArtifactType)
+	}
+	switch index.Manifests[0].ArtifactType {
+	case ArtifactTypeKitManifest:
+		return KitFormat, nil
+	case ArtifactTypeModelManifest:
+		return ModelPackFormat, nil
+	}
+	return UnknownModelFormat, fmt.Errorf("index is not a Model index: artifactType is %s, first child artifactType is %s", index.ArtifactType, index.Manifests[0].ArtifactType)
+}
+
+// ResolveIndexVariant picks the single child manifest descriptor that a `kit pull`/`kit
+// unpack` of a multi-platform or multi-variant ModelKit should act on: variant, if set,
+// is matched via SelectVariant against the KitOps-specific VariantAnnotation; otherwise
+// platform, if set, is matched via SelectPlatform against each child's OCI Platform.
+// Exactly one of variant or platform should be set by the caller (variant takes
+// precedence if both are); neither set is an error, since silently picking an arbitrary
+// child manifest would be the wrong behavior for an ambiguous multi-variant ModelKit.
+func ResolveIndexVariant(index *ocispec.Index, variant string, platform *ocispec.Platform) (ocispec.Descriptor, error) {
+	if variant != "" {
+		if desc, ok := SelectVariant(index.Manifests, variant); ok {
+			return desc, nil
+		}
+		return ocispec.Descriptor{}, fmt.Errorf("no manifest found for variant %q", variant)
+	}
+	if platform != nil {
+		if desc, ok := SelectPlatform(index.Manifests, platform); ok {
+			return desc, nil
+		}
+		return ocispec.Descriptor{}, fmt.Errorf("no manifest found for platform %s/%s", platform.OS, platform.Architecture)
+	}
+	return ocispec.Descriptor{}, fmt.Errorf("index %s has multiple manifests: specify a variant or platform to select one", index.ArtifactType)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func testIndexManifests() []ocispec.Descriptor {
+	return []ocispec.Descriptor{
+		{
+			Digest:      "sha256:fp16",
+			Annotations: map[string]string{VariantAnnotation: "fp16"},
+			Platform:    &ocispec.Platform{OS: "linux", Architecture: "amd64"},
+		},
+		{
+			Digest:      "sha256:int4awq",
+			Annotations: map[string]string{VariantAnnotation: "int4-awq"},
+			Platform:    &ocispec.Platform{OS: "linux", Architecture: "amd64", Variant: "cuda"},
+		},
+	}
+}
+
+func TestIsImageIndex(t *testing.T) {
+	assert.True(t, IsImageIndex(ocispec.MediaTypeImageIndex))
+	assert.False(t, IsImageIndex(ocispec.MediaTypeImageManifest))
+}
+
+func TestSelectVariant(t *testing.T) {
+	manifests := testIndexManifests()
+
+	desc, ok := SelectVariant(manifests, "int4-awq")
+	if assert.True(t, ok) {
+		assert.Equal(t, "sha256:int4awq", string(desc.Digest))
+	}
+
+	_, ok = SelectVariant(manifests, "int8")
+	assert.False(t, ok)
+}
+
+func TestSelectPlatform(t *testing.T) {
+	manifests := testIndexManifests()
+
+	desc, ok := SelectPlatform(manifests, &ocispec.Platform{OS: "linux", Architecture: "amd64", Variant: "cuda"})
+	if assert.True(t, ok) {
+		assert.Equal(t, "sha256:int4awq", string(desc.Digest))
+	}
+
+	_, ok = SelectPlatform(manifests, &ocispec.Platform{OS: "windows", Architecture: "amd64"})
+	assert.False(t, ok)
+
+	_, ok = SelectPlatform(manifests, nil)
+	assert.False(t, ok)
+}
+
+func TestModelFormatForIndex(t *testing.T) {
+	format, err := ModelFormatForIndex(&ocispec.Index{ArtifactType: ArtifactTypeKitIndex})
+	if assert.NoError(t, err) {
+		assert.Equal(t, KitFormat, format)
+	}
+
+	format, err = ModelFormatForIndex(&ocispec.Index{
+		Manifests: []ocispec.Descriptor{{ArtifactType: ArtifactTypeKitManifest}},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, KitFormat, format, "falls back to the first child manifest's artifactType")
+	}
+
+	_, err = ModelFormatForIndex(&ocispec.Index{})
+	assert.Error(t, err, "an index with no manifests and no artifactType is unresolvable")
+}
+
+func TestResolveIndexVariant(t *testing.T) {
+	index := &ocispec.Index{Manifests: testIndexManifests()}
+
+	desc, err := ResolveIndexVariant(index, "int4-awq", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "sha256:int4awq", string(desc.Digest))
+	}
+
+	desc, err = ResolveIndexVariant(index, "", &ocispec.Platform{OS: "linux", Architecture: "amd64"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "sha256:fp16", string(desc.Digest))
+	}
+
+	_, err = ResolveIndexVariant(index, "", nil)
+	assert.Error(t, err, "with neither variant nor platform set, the index is ambiguous")
+
+	_, err = ResolveIndexVariant(index, "int8", nil)
+	assert.Error(t, err)
+}
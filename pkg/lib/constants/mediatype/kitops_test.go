@@ -28,6 +28,9 @@ func TestParseKitopsMediaType(t *testing.T) {
 		"application/vnd.kitops.modelkit.config.v1+json",
 		"application/vnd.kitops.modelkit.model.v1.tar",
 		"application/vnd.kitops.modelkit.model.v1.tar+gzip",
+		"application/vnd.kitops.modelkit.model.v1.tar+zstd",
+		"application/vnd.kitops.modelkit.model.v1.tar+estargz",
+		"application/vnd.kitops.modelkit.model.v1.tar+gzip+encrypted",
 		"application/vnd.kitops.modelkit.modelpart.v1.tar",
 		"application/vnd.kitops.modelkit.modelpart.v1.tar+gzip",
 		"application/vnd.kitops.modelkit.dataset.v1.tar",
@@ -0,0 +1,54 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseManifestFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ManifestFormat
+		wantErr bool
+	}{
+		{in: "", want: KitopsFormat},
+		{in: "kitops", want: KitopsFormat},
+		{in: "modelpack", want: ModelPackFormat},
+		{in: "bogus", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseManifestFormat(tt.in)
+		if tt.wantErr {
+			assert.Error(t, err)
+			continue
+		}
+		if assert.NoError(t, err) {
+			assert.Equal(t, tt.want, got)
+		}
+	}
+}
+
+func TestNewDispatchesOnFormat(t *testing.T) {
+	kitops := New(KitopsFormat, ModelBaseType, ZstdCompression)
+	assert.Equal(t, "application/vnd.kitops.modelkit.model.v1.tar+zstd", kitops.String())
+
+	modelpack := New(ModelPackFormat, ModelBaseType, ZstdCompression)
+	assert.Equal(t, "application/vnd.cncf.model.weight.v1.tar+zstd", modelpack.String())
+}
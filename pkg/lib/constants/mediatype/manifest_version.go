@@ -0,0 +1,46 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mediatype
+
+import "fmt"
+
+// PackManifestVersion selects which generation of manifest shape
+// pack.PackModelKitManifest builds, mirroring oras-go's own oras.PackManifestVersion.
+// ModelFormatForManifest already understands both shapes, so packing either version
+// round-trips through the same resolution logic a puller/unpacker uses.
+type PackManifestVersion int
+
+const (
+	// KitManifestVersion1_0 embeds the discriminator in Config.MediaType, matching
+	// every ModelKit this tree produced before ArtifactType existed.
+	KitManifestVersion1_0 PackManifestVersion = iota
+	// KitManifestVersion1_1 follows the OCI image-spec v1.1 direction: an empty config
+	// descriptor (see IsEmptyConfig) and ArtifactType as the sole discriminator.
+	KitManifestVersion1_1
+)
+
+// String renders v for error messages and logging.
+func (v PackManifestVersion) String() string {
+	switch v {
+	case KitManifestVersion1_0:
+		return "1.0"
+	case KitManifestVersion1_1:
+		return "1.1"
+	default:
+		return fmt.Sprintf("PackManifestVersion(%d)", int(v))
+	}
+}
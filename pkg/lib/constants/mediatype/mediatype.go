@@ -19,7 +19,9 @@ package mediatype
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/kitops-ml/kitops/pkg/lib/compression"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -61,6 +63,11 @@ const (
 	GzipCompression
 	GzipFastestCompression
 	ZstdCompression
+	// EstargzCompression marks a layer as seekable gzip (eStargz-style): a regular
+	// gzip-compatible tar stream with a table of contents appended as extra gzip
+	// members, letting a range-fetch client (`kit unpack --filter`, future `kit mount`)
+	// download and verify only the files it needs. See package compression's estargz.go.
+	EstargzCompression
 )
 
 type Format int
@@ -72,6 +79,14 @@ const (
 )
 
 func ParseMediaType(s string) (MediaType, error) {
+	if inner, encrypted := StripEncryption(s); encrypted {
+		mt, err := ParseMediaType(inner)
+		if err != nil {
+			return nil, err
+		}
+		return &encryptedMediaType{mt}, nil
+	}
+
 	if s == "application/vnd.kitops.modelkit.config.v1+json" {
 		return &kitopsMediaType{
 			baseType: ConfigBaseType,
@@ -129,19 +144,101 @@ func NewKit(base BaseType, comp CompressionType) MediaType {
 	}
 }
 
+// ManifestFormat selects which family of media types a ModelKit's config and layers
+// use: this tree's own application/vnd.kitops.modelkit.* types, or the CNCF
+// ModelPack-compliant application/vnd.cncf.model.* types. `kit pack`/`kit push`
+// negotiate which family to write via --manifest-format; pull and unpack accept
+// either, since both already dispatch on BaseType through the MediaType interface
+// rather than comparing media type strings.
+type ManifestFormat string
+
+const (
+	KitopsFormat    ManifestFormat = "kitops"
+	ModelPackFormat ManifestFormat = "modelpack"
+)
+
+// ParseManifestFormat parses the --manifest-format flag value accepted by
+// `pack`/`push`. An empty string means "unset", which callers should treat the same
+// as KitopsFormat to keep existing ModelKits' on-disk format unchanged by default.
+func ParseManifestFormat(s string) (ManifestFormat, error) {
+	switch ManifestFormat(s) {
+	case "", KitopsFormat:
+		return KitopsFormat, nil
+	case ModelPackFormat:
+		return ModelPackFormat, nil
+	default:
+		return "", fmt.Errorf("invalid manifest format %q: must be one of %q, %q", s, KitopsFormat, ModelPackFormat)
+	}
+}
+
+// New builds the layer or config MediaType for base/comp in the family format
+// selects, so a caller negotiating --manifest-format doesn't need its own
+// kitops-vs-modelpack switch alongside NewKit.
+func New(format ManifestFormat, base BaseType, comp CompressionType) MediaType {
+	if format == ModelPackFormat {
+		return &modelpackMediatype{baseType: base, compressionType: comp, format: TarFormat}
+	}
+	return NewKit(base, comp)
+}
+
 func ParseCompression(c string) (CompressionType, error) {
-	switch c {
-	case "", "none":
-		return NoneCompression, nil
+	if c == "" {
+		c = "none"
+	}
+	if _, ok := compression.Lookup(c); !ok {
+		return UnknownCompression, fmt.Errorf("invalid compression %s", c)
+	}
+	return compressionTypeForName(c), nil
+}
+
+// compressionTypeForName maps a registered codec name to its CompressionType. It
+// exists alongside codecName (its inverse) because MediaType.Compression() and the
+// kitops/modelpack media type switches are typed on CompressionType, not codec name.
+func compressionTypeForName(name string) CompressionType {
+	switch name {
+	case "none":
+		return NoneCompression
 	case "gzip":
-		return GzipCompression, nil
+		return GzipCompression
 	case "gzip-fastest":
-		return GzipFastestCompression, nil
+		return GzipFastestCompression
 	case "zstd":
-		return ZstdCompression, nil
+		return ZstdCompression
+	case "estargz":
+		return EstargzCompression
 	default:
-		return UnknownCompression, fmt.Errorf("invalid compression %s", c)
+		return UnknownCompression
+	}
+}
+
+// codecName returns c's registered codec name, or "" if c doesn't correspond to one
+// (e.g. UnknownCompression).
+func (c CompressionType) codecName() string {
+	switch c {
+	case NoneCompression:
+		return "none"
+	case GzipCompression:
+		return "gzip"
+	case GzipFastestCompression:
+		return "gzip-fastest"
+	case ZstdCompression:
+		return "zstd"
+	case EstargzCompression:
+		return "estargz"
+	default:
+		return ""
+	}
+}
+
+// Codec returns the compression.Codec registered for c, so callers that need to
+// actually compress or decompress bytes don't have to re-implement a mediatype switch
+// of their own.
+func (c CompressionType) Codec() (compression.Codec, bool) {
+	name := c.codecName()
+	if name == "" {
+		return nil, false
 	}
+	return compression.Lookup(name)
 }
 
 func ParseFormat(f string) (Format, error) {
@@ -155,13 +252,10 @@ func ParseFormat(f string) (Format, error) {
 }
 
 func IsValidCompression(c string) error {
-	// Not supporting zstd for now; no stable implementation available
-	switch c {
-	case "none", "gzip", "gzip-fastest":
-		return nil
-	default:
-		return fmt.Errorf("invalid compression type: must be one of 'none', 'gzip', or 'gzip-fastest'")
+	if _, ok := compression.Lookup(c); !ok {
+		return fmt.Errorf("invalid compression type: must be one of %s", strings.Join(compression.Names(), ", "))
 	}
+	return nil
 }
 
 func FormatMediaTypeForUser(mediatype string) string {
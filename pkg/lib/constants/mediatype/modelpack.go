@@ -71,14 +71,14 @@ func (mt *modelpackMediatype) formatAndCompression() string {
 	case RawFormat:
 		return "raw"
 	case TarFormat:
-		switch mt.compressionType {
-		case NoneCompression:
-			return "tar"
-		case GzipCompression, GzipFastestCompression:
-			return "tar+gzip"
-		case ZstdCompression:
-			return "tar+zstd"
+		codec, ok := mt.compressionType.Codec()
+		if !ok {
+			return "invalid mediatype"
 		}
+		if suffix := codec.MediaTypeSuffix(); suffix != "" {
+			return "tar+" + suffix
+		}
+		return "tar"
 	}
 	return "invalid mediatype"
 }
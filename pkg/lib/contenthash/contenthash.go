@@ -0,0 +1,168 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package contenthash computes a deterministic merkle digest over an unpacked
+// ModelKit subtree, so that unpack can verify what landed on disk matches what was
+// packed, and so future incremental unpacks can skip re-hashing unchanged files.
+package contenthash
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kitops-ml/kitops/pkg/lib/filesystem"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// SidecarFileName is the name of the on-disk cache file written alongside an unpacked
+// subtree, recording the per-entry digests computed for it.
+const SidecarFileName = ".kitops-contenthash"
+
+// TreeDigestAnnotation carries the merkle root of a layer's subtree, so Checksum's
+// result can be verified against it after unpack. Nothing in this tree writes it when
+// packing; unpack only reads it from layers that already carry it.
+const TreeDigestAnnotation = "org.kitops.tree.digest"
+
+// Checksum walks the subtree rooted at root, computing a deterministic digest over
+// every entry's path, header metadata, and content. Paths matching excludes (glob
+// patterns, with '**' matching across directories, mirroring unpack's own exclude
+// filtering) are skipped. The result is order-independent and reusable as a cache key
+// for incremental unpacks.
+func Checksum(root string, excludes []string) (digest.Digest, error) {
+	tree, err := buildTree(root, excludes)
+	if err != nil {
+		return "", err
+	}
+	return rootDigest(tree), nil
+}
+
+// buildTree walks root and indexes every non-excluded entry into a radixTree, which
+// Checksum reduces to a single digest and WriteSidecar serializes to disk.
+func buildTree(root string, excludes []string) (*radixTree, error) {
+	root = filepath.Clean(root)
+	tree := newRadixTree()
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		relPath := filepath.ToSlash(strings.TrimPrefix(path, root+string(filepath.Separator)))
+		if d.Name() == SidecarFileName {
+			return nil
+		}
+		if filesystem.MatchesAnyGlob(excludes, relPath) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		e, err := hashEntry(path, relPath, d)
+		if err != nil {
+			return err
+		}
+		tree.insert(e)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", root, err)
+	}
+	return tree, nil
+}
+
+// rootDigest computes the SHA-256 digest over an in-order stream of
+// (path, header-digest, content-digest) tuples.
+func rootDigest(tree *radixTree) digest.Digest {
+	h := sha256.New()
+	tree.walkSorted(func(e entry) {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\n", e.path, e.headerDigest, e.contentDigest)
+	})
+	return digest.NewDigestFromEncoded(digest.SHA256, hex.EncodeToString(h.Sum(nil)))
+}
+
+// hashEntry computes the header and content digests for a single walked path.
+func hashEntry(fullPath, relPath string, d fs.DirEntry) (entry, error) {
+	info, err := d.Info()
+	if err != nil {
+		return entry{}, fmt.Errorf("failed to stat %s: %w", fullPath, err)
+	}
+
+	var linkTarget string
+	if info.Mode()&os.ModeSymlink != 0 {
+		linkTarget, err = os.Readlink(fullPath)
+		if err != nil {
+			return entry{}, fmt.Errorf("failed to read symlink %s: %w", fullPath, err)
+		}
+	}
+
+	headerDigest := HeaderDigest(relPath, info.Mode(), info.Size(), linkTarget)
+
+	var contentDigest digest.Digest
+	if info.Mode().IsRegular() {
+		f, err := os.Open(fullPath)
+		if err != nil {
+			return entry{}, fmt.Errorf("failed to open %s: %w", fullPath, err)
+		}
+		defer f.Close()
+		contentDigest, err = digest.FromReader(f)
+		if err != nil {
+			return entry{}, fmt.Errorf("failed to hash %s: %w", fullPath, err)
+		}
+	}
+
+	return entry{path: relPath, headerDigest: headerDigest, contentDigest: contentDigest}, nil
+}
+
+// HeaderDigest computes the digest over an entry's header metadata (name, mode, size,
+// typeflag, symlink target). It is exported so callers that already have this
+// information in another form -- e.g. a tar.Header being extracted -- can compute a
+// comparable digest without re-statting the file on disk.
+func HeaderDigest(relPath string, mode fs.FileMode, size int64, linkTarget string) digest.Digest {
+	data := fmt.Sprintf("%s\x00%o\x00%d\x00%c\x00%s", relPath, mode, size, typeflagFor(mode), linkTarget)
+	return digest.FromString(data)
+}
+
+// ContentDigest computes the digest over a file's content bytes.
+func ContentDigest(b []byte) digest.Digest {
+	return digest.FromBytes(b)
+}
+
+// typeflagFor returns a single byte identifying the entry kind, mirroring the
+// distinctions that matter for tar headers (mode/size alone can't tell them apart).
+func typeflagFor(mode fs.FileMode) byte {
+	switch {
+	case mode&os.ModeSymlink != 0:
+		return 'L'
+	case mode.IsDir():
+		return 'D'
+	case mode&os.ModeNamedPipe != 0:
+		return 'F'
+	case mode&os.ModeDevice != 0 && mode&os.ModeCharDevice != 0:
+		return 'C'
+	case mode&os.ModeDevice != 0:
+		return 'B'
+	default:
+		return '0'
+	}
+}
+
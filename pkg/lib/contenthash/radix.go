@@ -0,0 +1,104 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package contenthash
+
+import (
+	"sort"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// entry captures the two digests tracked for a single path: one over the entry's
+// header metadata (name, mode, size, typeflag, symlink target) and one over its
+// content (file bytes for regular files; empty digest for everything else).
+type entry struct {
+	path          string
+	headerDigest  digest.Digest
+	contentDigest digest.Digest
+}
+
+// radixNode is a node in the radix tree that indexes entries by path segment. The
+// tree is not immutable: insert adds and updates nodes in place. Each node is keyed
+// by one '/'-delimited path segment; a node with a non-nil entry marks that the path
+// built from the root down to it is a complete, indexed path.
+type radixNode struct {
+	entry    *entry
+	children map[string]*radixNode
+}
+
+// radixTree indexes a set of entries by cleaned, slash-separated path, and supports a
+// deterministic, path-sorted walk over them for hashing.
+type radixTree struct {
+	root *radixNode
+}
+
+func newRadixTree() *radixTree {
+	return &radixTree{root: &radixNode{children: map[string]*radixNode{}}}
+}
+
+func (t *radixTree) insert(e entry) {
+	node := t.root
+	for _, seg := range strings.Split(e.path, "/") {
+		child, ok := node.children[seg]
+		if !ok {
+			child = &radixNode{children: map[string]*radixNode{}}
+			node.children[seg] = child
+		}
+		node = child
+	}
+	stored := e
+	node.entry = &stored
+}
+
+// lookup returns the entry stored at path, if any.
+func (t *radixTree) lookup(path string) (entry, bool) {
+	node := t.root
+	for _, seg := range strings.Split(path, "/") {
+		child, ok := node.children[seg]
+		if !ok {
+			return entry{}, false
+		}
+		node = child
+	}
+	if node.entry == nil {
+		return entry{}, false
+	}
+	return *node.entry, true
+}
+
+// walkSorted visits every entry in the tree in deterministic, path-sorted order, which
+// makes the resulting merkle root independent of filesystem walk order.
+func (t *radixTree) walkSorted(fn func(entry)) {
+	var paths []string
+	byPath := map[string]entry{}
+	var collect func(n *radixNode)
+	collect = func(n *radixNode) {
+		if n.entry != nil {
+			paths = append(paths, n.entry.path)
+			byPath[n.entry.path] = *n.entry
+		}
+		for _, child := range n.children {
+			collect(child)
+		}
+	}
+	collect(t.root)
+	sort.Strings(paths)
+	for _, p := range paths {
+		fn(byPath[p])
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package contenthash
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// WriteSidecar records the per-entry digests computed by Checksum for root in a
+// SidecarFileName file alongside it, so a future Checksum/incremental unpack of the
+// same root can detect unchanged entries without re-reading file contents.
+func WriteSidecar(root string, excludes []string) error {
+	root = filepath.Clean(root)
+	tree, err := buildTree(root, excludes)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(root, SidecarFileName))
+	if err != nil {
+		return fmt.Errorf("failed to create sidecar: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	var werr error
+	tree.walkSorted(func(e entry) {
+		if werr != nil {
+			return
+		}
+		_, werr = fmt.Fprintf(w, "%s\t%s\t%s\n", e.path, e.headerDigest, e.contentDigest)
+	})
+	if werr != nil {
+		return fmt.Errorf("failed to write sidecar: %w", werr)
+	}
+	return w.Flush()
+}
+
+// readSidecar loads a previously-written sidecar file, if present. A missing sidecar
+// is not an error -- it just means there's nothing to compare against.
+func readSidecar(root string) (*radixTree, error) {
+	f, err := os.Open(filepath.Join(root, SidecarFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sidecar: %w", err)
+	}
+	defer f.Close()
+
+	tree := newRadixTree()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("malformed sidecar entry: %q", scanner.Text())
+		}
+		tree.insert(entry{path: fields[0], headerDigest: digest.Digest(fields[1]), contentDigest: digest.Digest(fields[2])})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sidecar: %w", err)
+	}
+	return tree, nil
+}
+
+// Sidecar is a root's parsed content-hash sidecar, loaded once via LoadSidecar and
+// queried per-entry via UpToDate -- the sidecar file is opened and parsed once, not
+// on every lookup, so a caller checking
+// every file in a layer against it (e.g. sync-mode unpack) stays linear in file count
+// rather than quadratic.
+type Sidecar struct {
+	tree *radixTree
+}
+
+// LoadSidecar loads root's sidecar file, if present, for repeated UpToDate lookups. A
+// missing sidecar is not an error: it returns a nil *Sidecar, against which UpToDate
+// always reports false.
+func LoadSidecar(root string) (*Sidecar, error) {
+	tree, err := readSidecar(root)
+	if err != nil {
+		return nil, err
+	}
+	if tree == nil {
+		return nil, nil
+	}
+	return &Sidecar{tree: tree}, nil
+}
+
+// UpToDate reports whether relPath's header+content digest in s matches header and
+// content. A false return (including a missing entry, or s being nil) means the
+// caller should treat the path as needing a fresh hash/extraction.
+func (s *Sidecar) UpToDate(relPath string, header, content digest.Digest) bool {
+	if s == nil {
+		return false
+	}
+	e, ok := s.tree.lookup(filepath.ToSlash(relPath))
+	if !ok {
+		return false
+	}
+	return e.headerDigest == header && e.contentDigest == content
+}
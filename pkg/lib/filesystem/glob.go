@@ -0,0 +1,69 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package filesystem
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MatchesAnyGlob returns true if path matches any of patterns. Patterns support the
+// '*' and '?' wildcards of path/filepath.Match, with the addition that '**' matches
+// across path separators (e.g. "datasets/**" matches any path under "datasets/").
+// Character classes ("[...]") and backslash escapes are not supported: those
+// characters match themselves literally. This is the
+// single glob-to-regex engine shared by unpack's ExcludePaths filtering and
+// contenthash's exclude matching, so the two don't drift against each other.
+func MatchesAnyGlob(patterns []string, path string) bool {
+	if len(patterns) == 0 || path == "" {
+		return false
+	}
+	path = filepath.ToSlash(path)
+	for _, pattern := range patterns {
+		if globPatternRegexp(pattern).MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// globPatternRegexp compiles a glob pattern (as accepted by MatchesAnyGlob) into a
+// regular expression anchored to the full path.
+func globPatternRegexp(pattern string) *regexp.Regexp {
+	pattern = filepath.ToSlash(pattern)
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i += 2
+		case pattern[i] == '*':
+			sb.WriteString("[^/]*")
+			i++
+		case pattern[i] == '?':
+			sb.WriteString("[^/]")
+			i++
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			i++
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
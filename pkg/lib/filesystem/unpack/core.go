@@ -18,23 +18,28 @@ package unpack
 
 import (
 	"archive/tar"
-	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/kitops-ml/kitops/pkg/artifact"
 	"github.com/kitops-ml/kitops/pkg/lib/constants"
 	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
+	"github.com/kitops-ml/kitops/pkg/lib/contenthash"
 	"github.com/kitops-ml/kitops/pkg/lib/filesystem"
 	"github.com/kitops-ml/kitops/pkg/lib/repo/util"
 	"github.com/kitops-ml/kitops/pkg/output"
 
+	"github.com/containers/ocicrypt"
+	encconfig "github.com/containers/ocicrypt/config"
 	modelspecv1 "github.com/modelpack/model-spec/specs-go/v1"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"oras.land/oras-go/v2/content"
@@ -119,6 +124,7 @@ func unpackRecursive(ctx context.Context, opts *UnpackOptions, visitedRefs []str
 	// We need to support older ModelKits (that were packed without diffIDs and digest
 	// in the config) for now, so we need to continue using the old structure.
 	var modelPartIdx, codeIdx, datasetIdx, docsIdx, promptIdx int
+	var jobs []layerJob
 	for _, layerDesc := range manifest.Layers {
 		// This variable supports older-format tar layers (that don't include the
 		// layer path). For current ModelKits, this will be empty
@@ -222,9 +228,7 @@ func unpackRecursive(ctx context.Context, opts *UnpackOptions, visitedRefs []str
 		}
 
 		// TODO: handle DiffIDs when unpacking layers
-		if err := unpackLayer(ctx, store, layerDesc, relPath, opts.Overwrite, opts.IgnoreExisting, mediaType.Compression()); err != nil {
-			return fmt.Errorf("failed to unpack: %w", err)
-		}
+		jobs = append(jobs, layerJob{desc: layerDesc, relPath: relPath, compression: mediaType.Compression()})
 	}
 	output.Debugf("Unpacked %d model part layers", modelPartIdx)
 	output.Debugf("Unpacked %d code layers", codeIdx)
@@ -232,7 +236,106 @@ func unpackRecursive(ctx context.Context, opts *UnpackOptions, visitedRefs []str
 	output.Debugf("Unpacked %d docs layers", docsIdx)
 	output.Debugf("Unpacked %d prompt layers", promptIdx)
 
-	return nil
+	return unpackLayersConcurrently(ctx, store, jobs, opts)
+}
+
+// layerJob is a single layer's fully-resolved unpack target, computed up-front so that
+// the index-counter bookkeeping above (modelPartIdx, codeIdx, ...) stays single-threaded
+// and deterministic before jobs are handed off to the worker pool.
+type layerJob struct {
+	desc        ocispec.Descriptor
+	relPath     string
+	compression mediatype.CompressionType
+}
+
+// unpackLayersConcurrently unpacks jobs using a bounded pool of workers, each owning
+// its own fetch stream, gzip reader, and tar reader. The first error from any worker
+// cancels ctx so the remaining workers abort promptly instead of continuing to fetch
+// and extract layers whose outcome no longer matters.
+func unpackLayersConcurrently(ctx context.Context, store content.Storage, jobs []layerJob, opts *UnpackOptions) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	var resumeState *ExtractState
+	if opts.Resume {
+		state, err := loadExtractState(opts.UnpackDir)
+		if err != nil {
+			return fmt.Errorf("failed to load extract state: %w", err)
+		}
+		resumeState = state
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = min(4, runtime.GOMAXPROCS(0))
+	}
+	if concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobCh := make(chan layerJob)
+	go func() {
+		defer close(jobCh)
+		for _, job := range jobs {
+			if resumeState != nil && resumeState.isComplete(job.desc.Digest.Encoded()) {
+				output.Debugf("Layer %s already extracted, skipping", job.desc.Digest)
+				continue
+			}
+			select {
+			case jobCh <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		mux      progressMux
+		errOnce  sync.Once
+		firstErr error
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobCh {
+				err := unpackLayer(ctx, store, job.desc, job.relPath, opts.Overwrite, opts.IgnoreExisting, job.compression, opts.ExcludePaths, opts.PreserveOwnership, opts.Verify, opts.Sync, opts.DecryptionKeys, &mux, resumeState, opts.UnpackDir)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = fmt.Errorf("failed to unpack: %w", err)
+						cancel()
+					})
+					return
+				}
+				if resumeState != nil {
+					resumeState.markComplete(job.desc.Digest.Encoded())
+					if err := resumeState.save(opts.UnpackDir); err != nil {
+						output.Debugf("Failed to save extract state: %v", err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// progressMux serializes completion of each layer's progress logger, so that workers
+// unpacking layers concurrently don't interleave their bars' completion output.
+type progressMux struct {
+	mu sync.Mutex
+}
+
+func (m *progressMux) wait(logger *output.ProgressLogger) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	logger.Wait()
 }
 
 func unpackParent(ctx context.Context, ref string, optsIn *UnpackOptions, visitedRefs []string) error {
@@ -309,8 +412,30 @@ func unpackConfig(config *artifact.KitFile, unpackDir string, overwrite bool) er
 	return nil
 }
 
-func unpackLayer(ctx context.Context, store content.Storage, desc ocispec.Descriptor, unpackPath string, overwrite, ignoreExisting bool, compression mediatype.CompressionType) error {
-	rc, err := store.Fetch(ctx, desc)
+func unpackLayer(ctx context.Context, store content.Storage, desc ocispec.Descriptor, unpackPath string, overwrite, ignoreExisting bool, compression mediatype.CompressionType, excludePaths []string, preserveOwnership, verify, sync bool, decryptionKeys []string, mux *progressMux, resumeState *ExtractState, extractDir string) error {
+	if unpackPath != "" {
+		unpackPath = filepath.Dir(unpackPath)
+		if err := os.MkdirAll(unpackPath, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", unpackPath, err)
+		}
+	}
+
+	fileMeta, err := fileMetadataFromAnnotations(desc)
+	if err != nil {
+		output.Debugf("Ignoring file metadata for layer %s: %v", desc.Digest, err)
+	}
+
+	if fetcher, ok := store.(rangeFetcher); ok && compression == mediatype.EstargzCompression && len(excludePaths) > 0 {
+		handled, err := unpackLayerEstargzFiltered(ctx, fetcher, desc, unpackPath, overwrite, ignoreExisting, excludePaths, fileMeta, preserveOwnership, sync, mux)
+		if err != nil {
+			return fmt.Errorf("failed to unpack layer %s: %w", desc.Digest, err)
+		}
+		if handled {
+			return finishUnpackedLayer(unpackPath, excludePaths, sync, verify, desc)
+		}
+	}
+
+	rc, err := fetchLayer(ctx, store, desc, resumeState, extractDir)
 	if err != nil {
 		return fmt.Errorf("failed get layer %s: %w", desc.Digest, err)
 	}
@@ -318,36 +443,123 @@ func unpackLayer(ctx context.Context, store content.Storage, desc ocispec.Descri
 	rc, logger = output.WrapUnpackReadCloser(desc.Size, rc)
 	defer rc.Close()
 
-	var cr io.ReadCloser
-	var cErr error
-	switch compression {
-	case mediatype.GzipCompression, mediatype.GzipFastestCompression:
-		cr, cErr = gzip.NewReader(rc)
-	case mediatype.NoneCompression:
-		cr = rc
+	decrypted, err := decryptLayerIfNeeded(rc, desc, decryptionKeys)
+	if err != nil {
+		return err
 	}
+	rc = decrypted
+
+	codec, ok := compression.Codec()
+	if !ok {
+		return fmt.Errorf("unsupported compression type for layer %s", desc.Digest)
+	}
+	cr, cErr := codec.Decompress(rc)
 	if cErr != nil {
 		return fmt.Errorf("error setting up decompress: %w", cErr)
 	}
 	defer cr.Close()
 	tr := tar.NewReader(cr)
 
-	if unpackPath != "" {
-		unpackPath = filepath.Dir(unpackPath)
-		if err := os.MkdirAll(unpackPath, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", unpackPath, err)
+	if err := extractTar(tr, unpackPath, overwrite, ignoreExisting, logger, excludePaths, fileMeta, preserveOwnership, sync); err != nil {
+		return err
+	}
+
+	mux.wait(logger)
+	return finishUnpackedLayer(unpackPath, excludePaths, sync, verify, desc)
+}
+
+// finishUnpackedLayer runs the post-extract steps common to both the sequential and
+// eStargz-filtered unpack paths: refreshing the content-hash sidecar and, if
+// requested, verifying the result against the layer's recorded tree digest.
+func finishUnpackedLayer(unpackPath string, excludePaths []string, sync, verify bool, desc ocispec.Descriptor) error {
+	if sync && unpackPath != "" {
+		if err := contenthash.WriteSidecar(unpackPath, excludePaths); err != nil {
+			output.Debugf("Failed to update content-hash cache for %s: %v", unpackPath, err)
 		}
 	}
 
-	if err := extractTar(tr, unpackPath, overwrite, ignoreExisting, logger); err != nil {
-		return err
+	if verify && unpackPath != "" {
+		if err := verifyUnpackedTree(unpackPath, excludePaths, desc); err != nil {
+			if rmErr := os.RemoveAll(unpackPath); rmErr != nil {
+				output.Debugf("Failed to roll back %s after verification failure: %v", unpackPath, rmErr)
+			}
+			return err
+		}
 	}
+	return nil
+}
 
-	logger.Wait()
+// verifyUnpackedTree recomputes a merkle digest over the just-extracted directory and
+// compares it against the layer's contenthash.TreeDigestAnnotation, if the layer was
+// packed with one. A mismatch is treated as corruption: the caller rolls back the
+// directory rather than leaving a partially-trusted tree on disk.
+func verifyUnpackedTree(dir string, excludePaths []string, desc ocispec.Descriptor) error {
+	expected, ok := desc.Annotations[contenthash.TreeDigestAnnotation]
+	if !ok {
+		output.Debugf("Layer %s has no tree digest annotation; skipping verification", desc.Digest)
+		return nil
+	}
+	actual, err := contenthash.Checksum(dir, excludePaths)
+	if err != nil {
+		return fmt.Errorf("failed to compute content hash for %s: %w", dir, err)
+	}
+	if actual.String() != expected {
+		return fmt.Errorf("content hash mismatch for %s: expected %s, got %s", dir, expected, actual)
+	}
 	return nil
 }
 
-func extractTar(tr *tar.Reader, extractDir string, overwrite, ignoreExisting bool, logger *output.ProgressLogger) (err error) {
+// fileMetadataFromAnnotations parses the modelspecv1.AnnotationFileMetadata annotation
+// (if present) off a layer descriptor, as written by fillDescAnnotations on pack.
+func fileMetadataFromAnnotations(desc ocispec.Descriptor) (*modelspecv1.FileMetadata, error) {
+	raw, ok := desc.Annotations[modelspecv1.AnnotationFileMetadata]
+	if !ok {
+		return nil, nil
+	}
+	var meta modelspecv1.FileMetadata
+	if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", modelspecv1.AnnotationFileMetadata, err)
+	}
+	return &meta, nil
+}
+
+// applyFileMetadata restores mode and modification time captured at pack time for outPath,
+// and -- if preserveOwnership is set and the process has sufficient privilege -- ownership.
+func applyFileMetadata(outPath string, meta *modelspecv1.FileMetadata, preserveOwnership bool) {
+	if meta == nil {
+		return
+	}
+	if err := os.Chmod(outPath, os.FileMode(meta.Mode)); err != nil {
+		output.Debugf("Failed to restore mode for %s: %v", outPath, err)
+	}
+	if !meta.ModTime.IsZero() {
+		if err := os.Chtimes(outPath, meta.ModTime, meta.ModTime); err != nil {
+			output.Debugf("Failed to restore mtime for %s: %v", outPath, err)
+		}
+	}
+	if !preserveOwnership {
+		return
+	}
+	if os.Geteuid() != 0 {
+		output.Debugf("Skipping ownership restore for %s: process does not have sufficient privilege", outPath)
+		return
+	}
+	if err := os.Lchown(outPath, int(meta.Uid), int(meta.Gid)); err != nil {
+		output.Debugf("Failed to restore ownership for %s: %v", outPath, err)
+	}
+}
+
+func extractTar(tr *tar.Reader, extractDir string, overwrite, ignoreExisting bool, logger *output.ProgressLogger, excludePaths []string, fileMeta *modelspecv1.FileMetadata, preserveOwnership, sync bool) (err error) {
+	var sidecar *contenthash.Sidecar
+	if sync {
+		// Loaded once per layer rather than once per file: syncRegularFile runs once
+		// per regular file in the archive, and re-reading+re-parsing the sidecar that
+		// often would make sync-mode extraction quadratic in file count.
+		sidecar, err = contenthash.LoadSidecar(extractDir)
+		if err != nil {
+			output.Debugf("Failed to load content-hash cache for %s: %v", extractDir, err)
+		}
+	}
 	for {
 		header, err := tr.Next()
 		if err == io.EOF {
@@ -356,6 +568,10 @@ func extractTar(tr *tar.Reader, extractDir string, overwrite, ignoreExisting boo
 		if err != nil {
 			return err
 		}
+		if filesystem.MatchesAnyGlob(excludePaths, header.Name) {
+			logger.Debugf("Excluding %s", header.Name)
+			continue
+		}
 		outPath := header.Name
 		if extractDir != "" {
 			outPath = filepath.Join(extractDir, header.Name)
@@ -380,6 +596,20 @@ func extractTar(tr *tar.Reader, extractDir string, overwrite, ignoreExisting boo
 			}
 
 		case tar.TypeReg:
+			if sync {
+				skip, err := syncRegularFile(tr, header, outPath, sidecar)
+				if err != nil {
+					return err
+				}
+				if skip {
+					continue
+				}
+				if fileMeta != nil && fileMeta.Name == filepath.Base(header.Name) {
+					applyFileMetadata(outPath, fileMeta, preserveOwnership)
+				}
+				continue
+			}
+
 			if fi, exists := filesystem.PathExists(outPath); exists {
 				if ignoreExisting {
 					output.Debugf("File %s already exists; skipping", outPath)
@@ -407,6 +637,62 @@ func extractTar(tr *tar.Reader, extractDir string, overwrite, ignoreExisting boo
 			if written != header.Size {
 				return fmt.Errorf("could not unpack file %s", outPath)
 			}
+			if fileMeta != nil && fileMeta.Name == filepath.Base(header.Name) {
+				applyFileMetadata(outPath, fileMeta, preserveOwnership)
+			}
+
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) {
+				return fmt.Errorf("illegal symlink target: %s is an absolute path", header.Linkname)
+			}
+			resolvedTarget := filepath.Join(filepath.Dir(outPath), header.Linkname)
+			if _, _, err := filesystem.VerifySubpath(extractDir, resolvedTarget); err != nil {
+				return fmt.Errorf("illegal symlink target for %s: %w", outPath, err)
+			}
+			if fi, exists := filesystem.PathExists(outPath); exists {
+				if !overwrite {
+					return fmt.Errorf("path '%s' already exists", outPath)
+				}
+				if err := removeForOverwrite(outPath, fi); err != nil {
+					return fmt.Errorf("failed to remove existing path %s: %w", outPath, err)
+				}
+			}
+			logger.Debugf("Creating symlink %s -> %s", outPath, header.Linkname)
+			if err := createSymlink(header.Linkname, outPath); err != nil {
+				return fmt.Errorf("failed to create symlink %s: %w", outPath, err)
+			}
+
+		case tar.TypeLink:
+			linkTarget := filepath.Join(extractDir, header.Linkname)
+			if _, _, err := filesystem.VerifySubpath(extractDir, linkTarget); err != nil {
+				return fmt.Errorf("illegal hardlink target for %s: %w", outPath, err)
+			}
+			if fi, exists := filesystem.PathExists(outPath); exists {
+				if !overwrite {
+					return fmt.Errorf("path '%s' already exists", outPath)
+				}
+				if err := removeForOverwrite(outPath, fi); err != nil {
+					return fmt.Errorf("failed to remove existing path %s: %w", outPath, err)
+				}
+			}
+			logger.Debugf("Creating hardlink %s -> %s", outPath, linkTarget)
+			if err := os.Link(linkTarget, outPath); err != nil {
+				return fmt.Errorf("failed to create hardlink %s: %w", outPath, err)
+			}
+
+		case tar.TypeChar, tar.TypeBlock, tar.TypeFifo:
+			if fi, exists := filesystem.PathExists(outPath); exists {
+				if !overwrite {
+					return fmt.Errorf("path '%s' already exists", outPath)
+				}
+				if err := removeForOverwrite(outPath, fi); err != nil {
+					return fmt.Errorf("failed to remove existing path %s: %w", outPath, err)
+				}
+			}
+			logger.Debugf("Creating device node %s", outPath)
+			if err := createDeviceNode(outPath, header); err != nil {
+				return fmt.Errorf("failed to create device node %s: %w", outPath, err)
+			}
 
 		default:
 			return fmt.Errorf("unrecognized type in archive: %s", header.Name)
@@ -415,6 +701,86 @@ func extractTar(tr *tar.Reader, extractDir string, overwrite, ignoreExisting boo
 	return nil
 }
 
+// syncRegularFile extracts a regular file entry in sync mode: if outPath already
+// exists and its header+content digest match what's recorded in sidecar (the layer's
+// root content-hash sidecar, loaded once by extractTar), the entry is left untouched
+// and skip is true. Otherwise the entry is written out (overwriting any existing file)
+// so the tree converges on what the layer describes, mirroring rsync's "only touch
+// what changed" behavior.
+func syncRegularFile(tr *tar.Reader, header *tar.Header, outPath string, sidecar *contenthash.Sidecar) (skip bool, err error) {
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file %s: %w", outPath, err)
+	}
+	headerDigest := contenthash.HeaderDigest(filepath.ToSlash(header.Name), header.FileInfo().Mode(), header.Size, "")
+	contentDigest := contenthash.ContentDigest(data)
+
+	if fi, exists := filesystem.PathExists(outPath); exists {
+		if !fi.Mode().IsRegular() {
+			if err := removeForOverwrite(outPath, fi); err != nil {
+				return false, fmt.Errorf("failed to remove existing path %s: %w", outPath, err)
+			}
+		} else if sidecar.UpToDate(header.Name, headerDigest, contentDigest) {
+			output.Debugf("File %s is up to date; skipping", outPath)
+			return true, nil
+		}
+	}
+
+	output.Debugf("Syncing file %s", outPath)
+	if err := os.WriteFile(outPath, data, header.FileInfo().Mode()); err != nil {
+		return false, fmt.Errorf("failed to write file %s: %w", outPath, err)
+	}
+	return false, nil
+}
+
+// decryptLayerIfNeeded decrypts rc if desc's media type carries mediatype.EncryptedSuffix,
+// using ocicrypt and the private keys in decryptionKeys. Layers that aren't encrypted are
+// returned unchanged. The returned reader's content is the layer's plaintext, compressed
+// tar stream -- decompression and untarring happen exactly as for an unencrypted layer.
+func decryptLayerIfNeeded(rc io.ReadCloser, desc ocispec.Descriptor, decryptionKeys []string) (io.ReadCloser, error) {
+	if !mediatype.IsEncryptedMediaTypeString(desc.MediaType) {
+		return rc, nil
+	}
+	if len(decryptionKeys) == 0 {
+		return nil, fmt.Errorf("layer %s is encrypted but no decryption keys were provided", desc.Digest)
+	}
+	privKeys, err := loadDecryptionKeys(decryptionKeys)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := encconfig.DecryptWithPrivKeys(privKeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up decryption for layer %s: %w", desc.Digest, err)
+	}
+	plain, _, err := ocicrypt.DecryptLayer(cc.DecryptConfig, rc, desc, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt layer %s: %w", desc.Digest, err)
+	}
+	return io.NopCloser(plain), nil
+}
+
+// loadDecryptionKeys reads each path in keyPaths as a PEM-encoded private key.
+func loadDecryptionKeys(keyPaths []string) ([][]byte, error) {
+	keys := make([][]byte, 0, len(keyPaths))
+	for _, path := range keyPaths {
+		key, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read decryption key %s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// removeForOverwrite removes an existing path so it can be replaced by a symlink,
+// hardlink, or device node; directories are rejected since we never want to recurse.
+func removeForOverwrite(path string, fi os.FileInfo) error {
+	if fi.IsDir() {
+		return fmt.Errorf("path '%s' already exists and is a directory", path)
+	}
+	return os.Remove(path)
+}
+
 func getIndex(list []string, s string) int {
 	for idx, item := range list {
 		if s == item {
@@ -0,0 +1,49 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package unpack
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// createSymlink creates a symlink at outPath pointing to target.
+func createSymlink(target, outPath string) error {
+	return os.Symlink(target, outPath)
+}
+
+// createDeviceNode creates the character, block, or FIFO device described by header at outPath.
+func createDeviceNode(outPath string, header *tar.Header) error {
+	mode := uint32(header.FileInfo().Mode().Perm())
+	switch header.Typeflag {
+	case tar.TypeChar:
+		mode |= unix.S_IFCHR
+	case tar.TypeBlock:
+		mode |= unix.S_IFBLK
+	case tar.TypeFifo:
+		mode |= unix.S_IFIFO
+	default:
+		return fmt.Errorf("unsupported device type flag: %c", header.Typeflag)
+	}
+	dev := unix.Mkdev(uint32(header.Devmajor), uint32(header.Devminor))
+	return unix.Mknod(outPath, mode, int(dev))
+}
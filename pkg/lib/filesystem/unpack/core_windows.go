@@ -0,0 +1,44 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package unpack
+
+import (
+	"archive/tar"
+	"os"
+
+	"github.com/kitops-ml/kitops/pkg/output"
+)
+
+// createSymlink creates a symlink at outPath pointing to target. Creating symlinks on
+// Windows requires the SeCreateSymbolicLinkPrivilege (or Developer Mode); when that
+// privilege is unavailable we degrade to a plain file containing the link target, so
+// unpack doesn't fail outright.
+func createSymlink(target, outPath string) error {
+	if err := os.Symlink(target, outPath); err != nil {
+		output.Logf(output.LogLevelWarn, "Cannot create symlink %s (missing privilege?): %s. Writing link target as a file instead.", outPath, err)
+		return os.WriteFile(outPath, []byte(target), 0644)
+	}
+	return nil
+}
+
+// createDeviceNode is unsupported on Windows; device nodes are skipped with a warning.
+func createDeviceNode(outPath string, header *tar.Header) error {
+	output.Logf(output.LogLevelWarn, "Skipping unsupported device node %s", outPath)
+	return nil
+}
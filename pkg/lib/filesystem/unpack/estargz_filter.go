@@ -0,0 +1,137 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package unpack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/kitops-ml/kitops/pkg/lib/compression"
+	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
+	"github.com/kitops-ml/kitops/pkg/lib/filesystem"
+	"github.com/kitops-ml/kitops/pkg/output"
+
+	modelspecv1 "github.com/modelpack/model-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// unpackLayerEstargzFiltered attempts to unpack desc using its eStargz table of
+// contents, fetching and decompressing only the gzip members not matched by
+// excludePaths -- so a `kit unpack --filter` that drops most of a model's shards never
+// downloads their bytes at all. handled is false, with no side effects, the moment any
+// part of the TOC-based path doesn't apply (most commonly: fetcher can't read the TOC
+// because the layer is encrypted, and FetchFrom refuses range requests against it), so
+// the caller falls back to the ordinary sequential fetchLayer/codec.Decompress path.
+func unpackLayerEstargzFiltered(ctx context.Context, fetcher rangeFetcher, desc ocispec.Descriptor, unpackPath string, overwrite, ignoreExisting bool, excludePaths []string, fileMeta *modelspecv1.FileMetadata, preserveOwnership, sync bool, mux *progressMux) (handled bool, err error) {
+	toc, err := compression.ReadTOC(&rangeReaderAt{ctx: ctx, fetcher: fetcher, desc: desc}, desc.Size)
+	if err != nil {
+		output.Debugf("Could not read estargz TOC for layer %s, falling back to sequential unpack: %v", desc.Digest, err)
+		return false, nil
+	}
+	if expected, ok := desc.Annotations[mediatype.EstargzTOCDigestAnnotation]; ok && expected != toc.Digest {
+		return false, fmt.Errorf("estargz TOC digest mismatch for layer %s: expected %s, got %s", desc.Digest, expected, toc.Digest)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(streamEstargzWantedEntries(ctx, fetcher, desc, toc, excludePaths, pw))
+	}()
+	rc, logger := output.WrapUnpackReadCloser(desc.Size, pr)
+	defer rc.Close()
+
+	gz, err := gzip.NewReader(rc)
+	if err != nil {
+		return true, fmt.Errorf("failed to decompress filtered estargz layer %s: %w", desc.Digest, err)
+	}
+	defer gz.Close()
+
+	if err := extractTar(tar.NewReader(gz), unpackPath, overwrite, ignoreExisting, logger, excludePaths, fileMeta, preserveOwnership, sync); err != nil {
+		return true, err
+	}
+	mux.wait(logger)
+	return true, nil
+}
+
+// streamEstargzWantedEntries fetches and digest-verifies every toc.Entries entry not
+// matched by excludePaths, in TOC order, and writes each entry's raw (still
+// gzip-compressed) member to w unchanged. That's the same concatenated-gzip-members
+// shape a full sequential fetch of the layer would produce, minus the excluded
+// members' bytes -- which were never fetched at all -- so a single gzip.Reader wrapped
+// around w transparently decompresses the result into a normal, if incomplete, tar
+// stream.
+func streamEstargzWantedEntries(ctx context.Context, fetcher rangeFetcher, desc ocispec.Descriptor, toc *compression.TOC, excludePaths []string, w io.Writer) error {
+	for _, entry := range toc.Entries {
+		if filesystem.MatchesAnyGlob(excludePaths, entry.Name) {
+			continue
+		}
+		start, end, ok := toc.EntryRange(entry.Name)
+		if !ok {
+			return fmt.Errorf("estargz TOC missing byte range for %s", entry.Name)
+		}
+		chunk, err := fetchEstargzChunk(ctx, fetcher, desc, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", entry.Name, err)
+		}
+		if _, err := compression.VerifyChunk(entry, chunk); err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk); err != nil {
+			return fmt.Errorf("failed to write %s: %w", entry.Name, err)
+		}
+	}
+	return nil
+}
+
+// fetchEstargzChunk fetches exactly the [start, end] inclusive byte range that
+// TOC.EntryRange reports for one entry, via fetcher's open-ended range fetch.
+func fetchEstargzChunk(ctx context.Context, fetcher rangeFetcher, desc ocispec.Descriptor, start, end int64) ([]byte, error) {
+	rc, err := fetcher.FetchFrom(ctx, desc, start)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	buf := make([]byte, end-start+1)
+	if _, err := io.ReadFull(rc, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// rangeReaderAt adapts a rangeFetcher's forward-only FetchFrom into the io.ReaderAt
+// compression.ReadTOC needs to locate and decode an eStargz layer's footer and TOC
+// without fetching the whole (possibly multi-gigabyte) blob first. Each ReadAt opens a
+// fresh ranged fetch and reads exactly len(p) bytes from it; that's wasteful for
+// general-purpose random access, but ReadTOC only ever calls it twice -- once for the
+// fixed-size footer, once for the TOC it points to -- so it's not worth pooling
+// connections over.
+type rangeReaderAt struct {
+	ctx     context.Context
+	fetcher rangeFetcher
+	desc    ocispec.Descriptor
+}
+
+func (r *rangeReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	rc, err := r.fetcher.FetchFrom(r.ctx, r.desc, off)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+	return io.ReadFull(rc, p)
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/kitops-ml/kitops/pkg/artifact"
+	"github.com/kitops-ml/kitops/pkg/lib/filesystem"
 )
 
 var validFilterTypes = []string{"kitfile", "model", "datasets", "code", "prompts", "docs"}
@@ -31,12 +32,21 @@ var validFilterTypes = []string{"kitfile", "model", "datasets", "code", "prompts
 type FilterConf struct {
 	BaseTypes []string
 	Filters   []string
+	// ExcludePaths is a list of glob patterns (supporting '**' to match across
+	// directories) matched against a layer's path; matching layers are skipped
+	// entirely, regardless of BaseTypes/Filters.
+	ExcludePaths []string
 }
 
 func (fc *FilterConf) matches(baseType, field string) bool {
 	return fc.matchesBaseType(baseType) && fc.matchesField(field)
 }
 
+// excludesPath returns true if path matches one of fc.ExcludePaths.
+func (fc *FilterConf) excludesPath(path string) bool {
+	return filesystem.MatchesAnyGlob(fc.ExcludePaths, path)
+}
+
 func (fc *FilterConf) matchesBaseType(baseType string) bool {
 	return slices.Contains(fc.BaseTypes, baseType)
 }
@@ -98,20 +108,20 @@ func shouldUnpackLayer(layer any, filters []FilterConf) bool {
 		}
 		return false
 	case artifact.Model:
-		return matchesFilters("model", l.Name, filters) || matchesFilters("model", l.Path, filters)
+		return !isPathExcluded(l.Path, filters) && (matchesFilters("model", l.Name, filters) || matchesFilters("model", l.Path, filters))
 	case artifact.ModelPart:
-		return matchesFilters("model", l.Name, filters) || matchesFilters("model", l.Path, filters)
+		return !isPathExcluded(l.Path, filters) && (matchesFilters("model", l.Name, filters) || matchesFilters("model", l.Path, filters))
 	case artifact.Docs:
 		// Docs does not have an ID/name field so we can only match on path
-		return matchesFilters("docs", l.Path, filters)
+		return !isPathExcluded(l.Path, filters) && matchesFilters("docs", l.Path, filters)
 	case artifact.DataSet:
-		return matchesFilters("datasets", l.Name, filters) || matchesFilters("datasets", l.Path, filters)
+		return !isPathExcluded(l.Path, filters) && (matchesFilters("datasets", l.Name, filters) || matchesFilters("datasets", l.Path, filters))
 	case artifact.Code:
 		// Code does not have a ID/name field so we can only match on path
-		return matchesFilters("code", l.Path, filters)
+		return !isPathExcluded(l.Path, filters) && matchesFilters("code", l.Path, filters)
 	case artifact.Prompt:
 		// Prompts do not have a ID/name field so we can only match on path
-		return matchesFilters("prompts", l.Path, filters)
+		return !isPathExcluded(l.Path, filters) && matchesFilters("prompts", l.Path, filters)
 	default:
 		return false
 	}
@@ -126,6 +136,17 @@ func matchesFilters(baseType, field string, filterConfs []FilterConf) bool {
 	return false
 }
 
+// isPathExcluded returns true if path matches an ExcludePaths pattern on any of filterConfs,
+// meaning the layer should never be unpacked (or fetched from the store) regardless of filters.
+func isPathExcluded(path string, filterConfs []FilterConf) bool {
+	for _, filterConf := range filterConfs {
+		if filterConf.excludesPath(path) {
+			return true
+		}
+	}
+	return false
+}
+
 // FiltersFromUnpackConf converts a (deprecated) unpackConf to a set of filters to enable supporting the old flags
 func FiltersFromUnpackConf(unpackKitfile, unpackModels, unpackCode, unpackDatasets, unpackDocs bool) []FilterConf {
 	filter := FilterConf{}
@@ -0,0 +1,308 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package unpack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kitops-ml/kitops/pkg/output"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+)
+
+// ExtractStateFileName is the sidecar persisted directly in an extract directory to
+// make an UnpackModelKit call with Resume: true resumable across restarts -- an
+// interrupted `kit dev` extraction of a multi-gigabyte model being the motivating
+// case.
+const ExtractStateFileName = ".kitops-extract-state.json"
+
+// extractStagingDirName holds each in-progress layer's raw (still-compressed) blob
+// bytes fetched so far, under extractDir. Kept separate from ExtractStateFileName so
+// a reader of the extracted tree sees one state file rather than a directory full of
+// partial blobs alongside it.
+const extractStagingDirName = ".kitops-extract-staging"
+
+// downloadCheckpointInterval bounds how much of a resumed fetch can be lost to an
+// interrupt between state saves: large enough that persisting the sidecar isn't a
+// per-chunk cost, small enough that losing one checkpoint on a multi-gigabyte layer
+// isn't a meaningful regression.
+const downloadCheckpointInterval = 8 << 20 // 8MiB
+
+// ExtractState tracks, per layer digest, how far a resumable extraction has
+// progressed. It's marshaled to ExtractStateFileName as layers finish downloading and
+// extracting, so a process killed mid-extract leaves enough behind for the next
+// Resume: true call to pick up where it left off instead of starting the whole
+// ModelKit over.
+type ExtractState struct {
+	mu sync.Mutex
+	// Layers is keyed by each layer descriptor's encoded digest.
+	Layers map[string]*LayerExtractState `json:"layers"`
+}
+
+// LayerExtractState is one layer's resumable-extraction progress.
+type LayerExtractState struct {
+	// Offset is how many bytes of the layer's blob have been written to its staging
+	// file so far.
+	Offset int64 `json:"offset"`
+	// Complete is set once the layer has been fully fetched, digest-verified, and
+	// extracted to disk. Only a Complete layer is skipped on resume. An interrupted
+	// download resumes from the bytes already staged; a layer whose blob finished
+	// downloading but failed to extract is fetched again from zero, since its staging
+	// file is deleted when the extractor closes its reader.
+	Complete bool `json:"complete"`
+}
+
+// loadExtractState reads ExtractStateFileName from extractDir, returning a fresh,
+// empty state (not an error) if it doesn't exist yet -- the common case for a first,
+// uninterrupted run.
+func loadExtractState(extractDir string) (*ExtractState, error) {
+	data, err := os.ReadFile(filepath.Join(extractDir, ExtractStateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return &ExtractState{Layers: map[string]*LayerExtractState{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extract state: %w", err)
+	}
+	var state ExtractState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse extract state: %w", err)
+	}
+	if state.Layers == nil {
+		state.Layers = map[string]*LayerExtractState{}
+	}
+	return &state, nil
+}
+
+// save writes state to ExtractStateFileName under extractDir via a temp file and
+// rename, so a process killed mid-write never leaves a truncated, unparseable sidecar
+// for the next Resume: true call to trip over.
+func (s *ExtractState) save(extractDir string) error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal extract state: %w", err)
+	}
+
+	path := filepath.Join(extractDir, ExtractStateFileName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write extract state: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// isComplete reports whether digest's layer is already fully extracted.
+func (s *ExtractState) isComplete(digest string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	layerState, ok := s.Layers[digest]
+	return ok && layerState.Complete
+}
+
+// markComplete flags digest's layer as fully extracted, so a later resume skips it
+// entirely.
+func (s *ExtractState) markComplete(digest string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forLayerLocked(digest).Complete = true
+}
+
+// setOffset records how many bytes of digest's layer blob have been staged so far.
+func (s *ExtractState) setOffset(digest string, offset int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.forLayerLocked(digest).Offset = offset
+}
+
+// resetLayer clears digest's progress entirely, for when a staged blob fails its
+// post-download digest check and has to be re-fetched from byte zero.
+func (s *ExtractState) resetLayer(digest string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Layers[digest] = &LayerExtractState{}
+}
+
+func (s *ExtractState) forLayerLocked(digest string) *LayerExtractState {
+	layerState, ok := s.Layers[digest]
+	if !ok {
+		layerState = &LayerExtractState{}
+		s.Layers[digest] = layerState
+	}
+	return layerState
+}
+
+// rangeFetcher is implemented by remote.Repository. A store that doesn't implement it
+// -- a local OCI layout store, which is already a fast local read -- just gets
+// fetched from byte zero every time, same as before Resume existed.
+type rangeFetcher interface {
+	FetchFrom(ctx context.Context, target ocispec.Descriptor, offset int64) (io.ReadCloser, error)
+}
+
+// fetchLayer returns a reader over desc's content: store.Fetch directly when state is
+// nil (Resume not requested) or store can't do range fetches, or -- otherwise -- a
+// handle onto a staged, digest-verified copy of the blob built up (and resumed)
+// across calls via fetchLayerResumable.
+func fetchLayer(ctx context.Context, store content.Storage, desc ocispec.Descriptor, state *ExtractState, extractDir string) (io.ReadCloser, error) {
+	fetcher, ok := store.(rangeFetcher)
+	if !ok || state == nil {
+		return store.Fetch(ctx, desc)
+	}
+	return fetchLayerResumable(ctx, fetcher, desc, state, extractDir)
+}
+
+// fetchLayerResumable downloads desc's blob into a staging file under extractDir,
+// continuing from wherever a previous attempt left off, digest-verifies the complete
+// result, and returns an open handle to it ready for decompression and extraction.
+// The staging file is deleted once the returned reader is closed, since by then its
+// content has been copied out by the extractor and there's no reason to keep a second,
+// still-compressed copy of a possibly multi-gigabyte layer on disk.
+func fetchLayerResumable(ctx context.Context, fetcher rangeFetcher, desc ocispec.Descriptor, state *ExtractState, extractDir string) (io.ReadCloser, error) {
+	stagingDir := filepath.Join(extractDir, extractStagingDirName)
+	if err := os.MkdirAll(stagingDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create extract staging directory: %w", err)
+	}
+	digestKey := desc.Digest.Encoded()
+	stagingPath := filepath.Join(stagingDir, digestKey)
+
+	offset := int64(0)
+	if fi, err := os.Stat(stagingPath); err == nil && fi.Size() <= desc.Size {
+		offset = fi.Size()
+	}
+
+	if offset < desc.Size {
+		newOffset, err := downloadLayerToStaging(ctx, fetcher, desc, stagingPath, offset, state, extractDir)
+		if err != nil {
+			return nil, err
+		}
+		offset = newOffset
+	}
+
+	f, err := os.Open(stagingPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open staged layer %s: %w", desc.Digest, err)
+	}
+	if err := verifyStagedLayer(f, desc); err != nil {
+		f.Close()
+		output.Debugf("Staged layer %s failed verification, discarding it: %v", desc.Digest, err)
+		if rmErr := os.Remove(stagingPath); rmErr != nil {
+			output.Debugf("Failed to remove invalid staging file %s: %v", stagingPath, rmErr)
+		}
+		state.resetLayer(digestKey)
+		if saveErr := state.save(extractDir); saveErr != nil {
+			output.Debugf("Failed to save extract state: %v", saveErr)
+		}
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to rewind staged layer %s: %w", desc.Digest, err)
+	}
+	return &stagingFileCloser{File: f, path: stagingPath}, nil
+}
+
+// downloadLayerToStaging fetches desc's blob from fromOffset via fetcher.FetchFrom and
+// appends it to stagingPath, checkpointing state every downloadCheckpointInterval
+// bytes so an interrupt doesn't lose more than one checkpoint's worth of progress. It
+// returns the file's new total size.
+func downloadLayerToStaging(ctx context.Context, fetcher rangeFetcher, desc ocispec.Descriptor, stagingPath string, fromOffset int64, state *ExtractState, extractDir string) (int64, error) {
+	rc, err := fetcher.FetchFrom(ctx, desc, fromOffset)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resume fetch for layer %s at offset %d: %w", desc.Digest, fromOffset, err)
+	}
+	defer rc.Close()
+
+	f, err := os.OpenFile(stagingPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open staging file for layer %s: %w", desc.Digest, err)
+	}
+	defer f.Close()
+	if _, err := f.Seek(fromOffset, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek staging file for layer %s: %w", desc.Digest, err)
+	}
+
+	digestKey := desc.Digest.Encoded()
+	offset := fromOffset
+	sinceCheckpoint := int64(0)
+	buf := make([]byte, 1<<20) // 1MiB read buffer
+	for {
+		n, readErr := rc.Read(buf)
+		if n > 0 {
+			if _, err := f.Write(buf[:n]); err != nil {
+				return 0, fmt.Errorf("failed to write staging file for layer %s: %w", desc.Digest, err)
+			}
+			offset += int64(n)
+			sinceCheckpoint += int64(n)
+			if sinceCheckpoint >= downloadCheckpointInterval {
+				state.setOffset(digestKey, offset)
+				if err := state.save(extractDir); err != nil {
+					output.Debugf("Failed to checkpoint extract state: %v", err)
+				}
+				sinceCheckpoint = 0
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return 0, fmt.Errorf("failed to fetch layer %s: %w", desc.Digest, readErr)
+		}
+	}
+
+	state.setOffset(digestKey, offset)
+	if err := state.save(extractDir); err != nil {
+		output.Debugf("Failed to checkpoint extract state: %v", err)
+	}
+	return offset, nil
+}
+
+// verifyStagedLayer confirms f's full contents hash to desc.Digest before it's
+// trusted as "fully fetched" -- catching both a registry that serves different bytes
+// on resume (e.g. a tag was repointed mid-extraction) and corruption introduced by
+// the interrupted/resumed download itself.
+func verifyStagedLayer(f *os.File, desc ocispec.Descriptor) error {
+	verifier := desc.Digest.Verifier()
+	if _, err := io.Copy(verifier, f); err != nil {
+		return fmt.Errorf("failed to verify staged layer %s: %w", desc.Digest, err)
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("staged layer %s failed digest verification", desc.Digest)
+	}
+	return nil
+}
+
+// stagingFileCloser deletes its staging file once the caller is done reading it.
+type stagingFileCloser struct {
+	*os.File
+	path string
+}
+
+func (s *stagingFileCloser) Close() error {
+	closeErr := s.File.Close()
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		output.Debugf("Failed to remove staging file %s: %v", s.path, err)
+	}
+	return closeErr
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package harness
+
+import (
+	"context"
+	"fmt"
+)
+
+// LLMHarness runs a llama.cpp (llama-server) process against a single GGUF model
+// file. It predates ModelRuntime and is kept as the concrete llama.cpp server
+// controller that LlamaCppRuntime.Start delegates to.
+type LLMHarness struct {
+	Host       string
+	Port       int
+	ConfigHome string
+
+	proc *Process
+}
+
+// Init prepares the harness directory for a new server.
+func (h *LLMHarness) Init() error {
+	h.proc = &Process{ConfigHome: h.ConfigHome}
+	return h.proc.Init()
+}
+
+// Start launches llama-server against modelPath.
+func (h *LLMHarness) Start(modelPath string) error {
+	if h.proc == nil {
+		if err := h.Init(); err != nil {
+			return err
+		}
+	}
+	args := []string{
+		"--model", modelPath,
+		"--host", h.Host,
+		"--port", fmt.Sprintf("%d", h.Port),
+	}
+	return h.proc.StartCommand(context.Background(), "llama-server", args)
+}
+
+// Stop stops the running llama-server process, if any.
+func (h *LLMHarness) Stop() error {
+	if h.proc == nil {
+		h.proc = &Process{ConfigHome: h.ConfigHome}
+	}
+	return h.proc.Stop()
+}
@@ -0,0 +1,138 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package harness manages the local inference server `kit dev` starts and stops,
+// across the range of model runtimes that server can be (see ModelRuntime).
+package harness
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/kitops-ml/kitops/pkg/lib/constants"
+)
+
+// Process manages a single long-running inference server as a detached child
+// process, tracked via a pidfile under constants.HarnessPath so a later `kit dev
+// stop` -- a separate CLI invocation, with no memory of which ModelRuntime started
+// the server -- can still find and stop it.
+type Process struct {
+	ConfigHome string
+}
+
+func (p *Process) pidFilePath() string {
+	return constants.HarnessPath(p.ConfigHome) + string(os.PathSeparator) + constants.HarnessProcessFile
+}
+
+func (p *Process) logFilePath() string {
+	return constants.HarnessPath(p.ConfigHome) + string(os.PathSeparator) + constants.HarnessLogFile
+}
+
+// Init ensures the harness directory exists. It does not check for a running server;
+// StartCommand does that.
+func (p *Process) Init() error {
+	if err := os.MkdirAll(constants.HarnessPath(p.ConfigHome), 0755); err != nil {
+		return fmt.Errorf("failed to create harness directory: %w", err)
+	}
+	return nil
+}
+
+// StartCommand launches name with args as a detached background process, redirecting
+// its output to the harness log file and recording its PID so Stop can find it later.
+// It refuses to start a second server on top of one StartCommand already considers
+// running.
+func (p *Process) StartCommand(ctx context.Context, name string, args []string) error {
+	if running, _ := p.IsRunning(); running {
+		return fmt.Errorf("a dev server is already running; stop it first")
+	}
+
+	logFile, err := os.Create(p.logFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to create harness log file: %w", err)
+	}
+	defer logFile.Close()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	setDetached(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+
+	if err := os.WriteFile(p.pidFilePath(), []byte(strconv.Itoa(cmd.Process.Pid)), 0644); err != nil {
+		_ = cmd.Process.Kill()
+		return fmt.Errorf("failed to record server PID: %w", err)
+	}
+	// Release the child so it survives this process exiting; callers stop it via Stop,
+	// not by keeping this *exec.Cmd around.
+	return cmd.Process.Release()
+}
+
+// IsRunning reports whether the pidfile refers to a live process.
+func (p *Process) IsRunning() (bool, error) {
+	pid, err := p.readPID()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false, nil
+	}
+	// On Unix, FindProcess always succeeds; signal 0 actually probes liveness.
+	if err := proc.Signal(syscall.Signal(0)); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Stop signals the running server to terminate and removes the pidfile.
+func (p *Process) Stop() error {
+	pid, err := p.readPID()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	proc, err := os.FindProcess(pid)
+	if err == nil {
+		if err := proc.Signal(syscall.SIGTERM); err != nil && !strings.Contains(err.Error(), "process already finished") {
+			return fmt.Errorf("failed to stop dev server (pid %d): %w", pid, err)
+		}
+	}
+	return os.Remove(p.pidFilePath())
+}
+
+func (p *Process) readPID() (int, error) {
+	raw, err := os.ReadFile(p.pidFilePath())
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	if err != nil {
+		return 0, fmt.Errorf("corrupt harness pidfile: %w", err)
+	}
+	return pid, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// HarnessOptions carries the server configuration a ModelRuntime needs to start,
+// independent of which concrete runtime ends up serving the model.
+type HarnessOptions struct {
+	Host       string
+	Port       int
+	ConfigHome string
+}
+
+// ModelRuntime is a local inference server `kit dev` knows how to run a model
+// through. Detect scores how confident the runtime is that absPath (a file or
+// directory resolved from the Kitfile's model.path) is something it can serve, and
+// -- if so -- what to pass to Start as the entrypoint (a single model file, or a
+// model directory, depending on the runtime).
+type ModelRuntime interface {
+	// Name identifies the runtime for error messages and the --runtime override flag.
+	Name() string
+	// Detect inspects absPath and returns a confidence score (0 meaning "not a match"
+	// and no error), and the entrypoint Start should be called with if score > 0.
+	Detect(absPath string) (score int, entrypoint string, err error)
+	// Start launches the server for entrypoint (as returned by Detect) as a detached
+	// background process and returns once it has started; the server keeps running
+	// until it is stopped via Process.Stop.
+	Start(ctx context.Context, entrypoint string, opts HarnessOptions) error
+}
+
+// DefaultRuntimes returns every ModelRuntime `kit dev` tries, in no particular
+// order -- SelectRuntime resolves ties explicitly rather than relying on order here.
+func DefaultRuntimes() []ModelRuntime {
+	return []ModelRuntime{
+		&LlamaCppRuntime{},
+		&VLLMRuntime{},
+		&ONNXRuntime{},
+	}
+}
+
+// RuntimeByName returns the runtime in runtimes whose Name matches name, for a
+// `--runtime` override. Matching is case-insensitive since it's user-supplied.
+func RuntimeByName(name string, runtimes []ModelRuntime) (ModelRuntime, error) {
+	for _, rt := range runtimes {
+		if strings.EqualFold(rt.Name(), name) {
+			return rt, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown runtime %q", name)
+}
+
+// SelectRuntime picks the highest-scoring runtime in runtimes for absPath. It
+// returns an error if no runtime recognizes absPath, or if more than one runtime
+// ties for the highest score -- an ambiguity the caller should ask the user to
+// resolve with --runtime rather than silently guessing.
+func SelectRuntime(absPath string, runtimes []ModelRuntime) (ModelRuntime, string, error) {
+	type candidate struct {
+		runtime    ModelRuntime
+		entrypoint string
+	}
+	bestScore := 0
+	var best []candidate
+	for _, rt := range runtimes {
+		score, entrypoint, err := rt.Detect(absPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", rt.Name(), err)
+		}
+		if score <= 0 {
+			continue
+		}
+		switch {
+		case score > bestScore:
+			bestScore = score
+			best = []candidate{{rt, entrypoint}}
+		case score == bestScore:
+			best = append(best, candidate{rt, entrypoint})
+		}
+	}
+	if len(best) == 0 {
+		return nil, "", fmt.Errorf("no runtime recognized a model at %s", absPath)
+	}
+	if len(best) > 1 {
+		names := make([]string, len(best))
+		for i, c := range best {
+			names[i] = c.runtime.Name()
+		}
+		return nil, "", fmt.Errorf("multiple runtimes matched %s with equal confidence (%s); use --runtime to pick one", absPath, strings.Join(names, ", "))
+	}
+	return best[0].runtime, best[0].entrypoint, nil
+}
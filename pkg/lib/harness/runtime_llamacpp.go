@@ -0,0 +1,103 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ggufShardPattern matches llama.cpp's sharded GGUF naming convention, e.g.
+// "model-00001-of-00005.gguf". llama-server only needs to be pointed at the first
+// shard; it discovers the rest from the matching files in the same directory.
+var ggufShardPattern = regexp.MustCompile(`-(\d+)-of-(\d+)\.gguf$`)
+
+// LlamaCppRuntime serves single-file or sharded GGUF models via llama-server.
+type LlamaCppRuntime struct{}
+
+func (r *LlamaCppRuntime) Name() string { return "llama.cpp" }
+
+func (r *LlamaCppRuntime) Detect(absPath string) (int, string, error) {
+	stat, err := os.Lstat(absPath)
+	if err != nil {
+		return 0, "", err
+	}
+	if stat.Mode().IsRegular() {
+		if strings.HasSuffix(absPath, ".gguf") {
+			return 100, absPath, nil
+		}
+		return 0, "", nil
+	}
+	if !stat.IsDir() {
+		return 0, "", nil
+	}
+
+	var ggufFiles []string
+	if err := filepath.WalkDir(absPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && strings.HasSuffix(path, ".gguf") {
+			ggufFiles = append(ggufFiles, path)
+		}
+		return nil
+	}); err != nil {
+		return 0, "", fmt.Errorf("error searching for GGUF files in %s: %w", absPath, err)
+	}
+
+	switch len(ggufFiles) {
+	case 0:
+		return 0, "", nil
+	case 1:
+		return 100, ggufFiles[0], nil
+	}
+
+	// Multiple GGUF files: only a match if they're all shards of the same model;
+	// the entrypoint is whichever names itself shard 1.
+	var firstShard, prefix, total string
+	for i, f := range ggufFiles {
+		match := ggufShardPattern.FindStringSubmatch(f)
+		if match == nil {
+			return 0, "", fmt.Errorf("multiple unrelated GGUF files found in %s: %s and %s", absPath, ggufFiles[0], f)
+		}
+		if i == 0 {
+			prefix, total = strings.TrimSuffix(f, match[0]), match[2]
+		} else if strings.TrimSuffix(f, match[0]) != prefix || match[2] != total {
+			return 0, "", fmt.Errorf("multiple unrelated GGUF files found in %s: %s and %s", absPath, ggufFiles[0], f)
+		}
+		if match[1] == "00001" {
+			firstShard = f
+		}
+	}
+	if firstShard == "" {
+		return 0, "", fmt.Errorf("found sharded GGUF files in %s but none is shard 1", absPath)
+	}
+	return 100, firstShard, nil
+}
+
+func (r *LlamaCppRuntime) Start(ctx context.Context, entrypoint string, opts HarnessOptions) error {
+	h := &LLMHarness{Host: opts.Host, Port: opts.Port, ConfigHome: opts.ConfigHome}
+	if err := h.Init(); err != nil {
+		return err
+	}
+	return h.Start(entrypoint)
+}
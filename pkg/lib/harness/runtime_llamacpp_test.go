@@ -0,0 +1,77 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package harness
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLlamaCppRuntimeDetectSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	modelPath := filepath.Join(dir, "model.gguf")
+	assert.NoError(t, os.WriteFile(modelPath, []byte("x"), 0644))
+
+	rt := &LlamaCppRuntime{}
+	score, entrypoint, err := rt.Detect(modelPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Greater(t, score, 0)
+	assert.Equal(t, modelPath, entrypoint)
+}
+
+func TestLlamaCppRuntimeDetectShardedDirectory(t *testing.T) {
+	dir := t.TempDir()
+	shard1 := filepath.Join(dir, "model-00001-of-00002.gguf")
+	shard2 := filepath.Join(dir, "model-00002-of-00002.gguf")
+	assert.NoError(t, os.WriteFile(shard1, []byte("x"), 0644))
+	assert.NoError(t, os.WriteFile(shard2, []byte("x"), 0644))
+
+	rt := &LlamaCppRuntime{}
+	score, entrypoint, err := rt.Detect(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Greater(t, score, 0)
+	assert.Equal(t, shard1, entrypoint)
+}
+
+func TestLlamaCppRuntimeDetectAmbiguousFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.gguf"), []byte("x"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "b.gguf"), []byte("x"), 0644))
+
+	rt := &LlamaCppRuntime{}
+	_, _, err := rt.Detect(dir)
+	assert.ErrorContains(t, err, "unrelated GGUF files")
+}
+
+func TestLlamaCppRuntimeDetectNoMatch(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("x"), 0644))
+
+	rt := &LlamaCppRuntime{}
+	score, _, err := rt.Detect(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 0, score)
+}
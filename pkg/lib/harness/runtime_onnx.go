@@ -0,0 +1,80 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ONNXRuntime serves a single .onnx model file via onnxruntime_server.
+type ONNXRuntime struct{}
+
+func (r *ONNXRuntime) Name() string { return "onnxruntime" }
+
+func (r *ONNXRuntime) Detect(absPath string) (int, string, error) {
+	stat, err := os.Lstat(absPath)
+	if err != nil {
+		return 0, "", err
+	}
+	if stat.Mode().IsRegular() {
+		if strings.HasSuffix(absPath, ".onnx") {
+			return 80, absPath, nil
+		}
+		return 0, "", nil
+	}
+	if !stat.IsDir() {
+		return 0, "", nil
+	}
+
+	var onnxFiles []string
+	if err := filepath.WalkDir(absPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && strings.HasSuffix(path, ".onnx") {
+			onnxFiles = append(onnxFiles, path)
+		}
+		return nil
+	}); err != nil {
+		return 0, "", fmt.Errorf("error searching for ONNX files in %s: %w", absPath, err)
+	}
+	switch len(onnxFiles) {
+	case 0:
+		return 0, "", nil
+	case 1:
+		return 80, onnxFiles[0], nil
+	default:
+		return 0, "", fmt.Errorf("multiple ONNX files found in %s: %s and %s", absPath, onnxFiles[0], onnxFiles[1])
+	}
+}
+
+func (r *ONNXRuntime) Start(ctx context.Context, entrypoint string, opts HarnessOptions) error {
+	proc := &Process{ConfigHome: opts.ConfigHome}
+	if err := proc.Init(); err != nil {
+		return err
+	}
+	args := []string{
+		"--model_path", entrypoint,
+		"--http_port", fmt.Sprintf("%d", opts.Port),
+	}
+	return proc.StartCommand(ctx, "onnxruntime_server", args)
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package harness
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRuntime struct {
+	name       string
+	score      int
+	entrypoint string
+	err        error
+}
+
+func (f *fakeRuntime) Name() string { return f.name }
+func (f *fakeRuntime) Detect(absPath string) (int, string, error) {
+	return f.score, f.entrypoint, f.err
+}
+func (f *fakeRuntime) Start(ctx context.Context, entrypoint string, opts HarnessOptions) error {
+	return nil
+}
+
+func TestSelectRuntimePicksHighestScore(t *testing.T) {
+	runtimes := []ModelRuntime{
+		&fakeRuntime{name: "low", score: 10, entrypoint: "low-entry"},
+		&fakeRuntime{name: "high", score: 90, entrypoint: "high-entry"},
+	}
+	rt, entrypoint, err := SelectRuntime("/some/path", runtimes)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "high", rt.Name())
+	assert.Equal(t, "high-entry", entrypoint)
+}
+
+func TestSelectRuntimeErrorsOnTie(t *testing.T) {
+	runtimes := []ModelRuntime{
+		&fakeRuntime{name: "a", score: 50, entrypoint: "a-entry"},
+		&fakeRuntime{name: "b", score: 50, entrypoint: "b-entry"},
+	}
+	_, _, err := SelectRuntime("/some/path", runtimes)
+	assert.ErrorContains(t, err, "multiple runtimes matched")
+}
+
+func TestSelectRuntimeErrorsOnNoMatch(t *testing.T) {
+	runtimes := []ModelRuntime{
+		&fakeRuntime{name: "a", score: 0},
+	}
+	_, _, err := SelectRuntime("/some/path", runtimes)
+	assert.ErrorContains(t, err, "no runtime recognized")
+}
+
+func TestRuntimeByNameIsCaseInsensitive(t *testing.T) {
+	runtimes := []ModelRuntime{&fakeRuntime{name: "llama.cpp"}}
+	rt, err := RuntimeByName("LLAMA.CPP", runtimes)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "llama.cpp", rt.Name())
+
+	_, err = RuntimeByName("nonexistent", runtimes)
+	assert.ErrorContains(t, err, "unknown runtime")
+}
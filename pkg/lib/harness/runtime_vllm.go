@@ -0,0 +1,77 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VLLMRuntime serves a Hugging Face-format model directory (config.json plus one or
+// more .safetensors weight files) via `vllm serve`.
+type VLLMRuntime struct{}
+
+func (r *VLLMRuntime) Name() string { return "vllm" }
+
+func (r *VLLMRuntime) Detect(absPath string) (int, string, error) {
+	stat, err := os.Lstat(absPath)
+	if err != nil {
+		return 0, "", err
+	}
+	if !stat.IsDir() {
+		// vLLM needs a model directory, not a single weights file.
+		return 0, "", nil
+	}
+
+	if _, err := os.Stat(filepath.Join(absPath, "config.json")); err != nil {
+		return 0, "", nil
+	}
+
+	hasWeights := false
+	if err := filepath.WalkDir(absPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && strings.HasSuffix(path, ".safetensors") {
+			hasWeights = true
+		}
+		return nil
+	}); err != nil {
+		return 0, "", fmt.Errorf("error searching for safetensors weights in %s: %w", absPath, err)
+	}
+	if !hasWeights {
+		return 0, "", nil
+	}
+	return 90, absPath, nil
+}
+
+func (r *VLLMRuntime) Start(ctx context.Context, entrypoint string, opts HarnessOptions) error {
+	proc := &Process{ConfigHome: opts.ConfigHome}
+	if err := proc.Init(); err != nil {
+		return err
+	}
+	args := []string{
+		"serve", entrypoint,
+		"--host", opts.Host,
+		"--port", fmt.Sprintf("%d", opts.Port),
+	}
+	return proc.StartCommand(ctx, "vllm", args)
+}
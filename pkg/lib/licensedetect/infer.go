@@ -0,0 +1,81 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package licensedetect
+
+import (
+	"path/filepath"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+)
+
+// InferMissingLicenses scans the root directory of each section of kitfile that has
+// no license declared (model, each model part, each dataset, each code entry) for a
+// license file, and fills in the section's License field with whatever scanner
+// detects -- UnknownLicense included, so a later collectLicenses call (and anything
+// downstream of it, like SBOM generation or policy enforcement) sees an explicit
+// "UNKNOWN" rather than silently treating the section as having no license at all.
+// contextDir is the pack context root that section paths are relative to. Sections
+// that already declare a license are left untouched.
+func InferMissingLicenses(kitfile *artifact.KitFile, scanner *Scanner, contextDir string) error {
+	if kitfile.Model != nil && kitfile.Model.Path != "" {
+		if err := inferSection(&kitfile.Model.License, kitfile.Model.Path, scanner, contextDir); err != nil {
+			return err
+		}
+		for i := range kitfile.Model.Parts {
+			part := &kitfile.Model.Parts[i]
+			if part.Path == "" {
+				continue
+			}
+			if err := inferSection(&part.License, part.Path, scanner, contextDir); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range kitfile.DataSets {
+		ds := &kitfile.DataSets[i]
+		if ds.Path == "" {
+			continue
+		}
+		if err := inferSection(&ds.License, ds.Path, scanner, contextDir); err != nil {
+			return err
+		}
+	}
+	for i := range kitfile.Code {
+		c := &kitfile.Code[i]
+		if c.Path == "" {
+			continue
+		}
+		if err := inferSection(&c.License, c.Path, scanner, contextDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inferSection fills *license from a scan of contextDir/path, leaving it untouched if
+// it's already set.
+func inferSection(license *string, path string, scanner *Scanner, contextDir string) error {
+	if *license != "" {
+		return nil
+	}
+	detected, err := scanner.ScanDir(filepath.Join(contextDir, path))
+	if err != nil {
+		return err
+	}
+	*license = detected
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package licensedetect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferMissingLicensesLeavesExistingAlone(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "model"), 0755))
+
+	kitfile := &artifact.KitFile{
+		Model: &artifact.Model{Path: "model", License: "Apache-2.0"},
+	}
+	assert.NoError(t, InferMissingLicenses(kitfile, NewScanner(), dir))
+	assert.Equal(t, "Apache-2.0", kitfile.Model.License)
+}
+
+func TestInferMissingLicensesFillsUnknownWhenNoLicenseFile(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "code"), 0755))
+
+	kitfile := &artifact.KitFile{
+		Code: []artifact.Code{{Path: "code"}},
+	}
+	assert.NoError(t, InferMissingLicenses(kitfile, NewScanner(), dir))
+	assert.Equal(t, UnknownLicense, kitfile.Code[0].License)
+}
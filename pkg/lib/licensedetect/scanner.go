@@ -0,0 +1,146 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package licensedetect resolves license text found on disk to SPDX identifiers, for
+// auto-populating a Kitfile's empty license fields during `kit pack --infer-licenses`.
+package licensedetect
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/google/licensecheck"
+)
+
+const (
+	// DefaultCoverageThreshold is the fraction of a license file's text that must
+	// match a known license for Scanner to trust the match, rather than reporting
+	// UnknownLicense. 75% tolerates a README-style preamble or trailing notices
+	// without accepting a near-miss as a confident identification.
+	DefaultCoverageThreshold = 0.75
+	// DefaultMaxFileSize guards against scanning a huge file that just happens to be
+	// named LICENSE (e.g. a dataset accidentally checked in under that name).
+	DefaultMaxFileSize = 1 << 20 // 1 MiB
+
+	// UnknownLicense is returned when no confident SPDX match is found, so callers
+	// can report it explicitly rather than silently leaving the field blank.
+	UnknownLicense = "UNKNOWN"
+)
+
+// candidateLicenseFiles are filenames ScanDir checks for a license text, in priority
+// order, matching the conventions most packaging ecosystems (and GitHub) use.
+var candidateLicenseFiles = []string{
+	"LICENSE",
+	"LICENSE.txt",
+	"LICENSE.md",
+	"COPYING",
+	"COPYING.txt",
+}
+
+var (
+	sharedScannerOnce sync.Once
+	sharedScanner     *licensecheck.Scanner
+	sharedScannerErr  error
+)
+
+// sharedLicenseScanner returns the process-wide licensecheck.Scanner, built once:
+// constructing one compiles every builtin license's matcher, which is too expensive
+// to redo per call, and the resulting Scanner is safe for concurrent use -- the same
+// approach pkgsite's licensecheck integration takes.
+func sharedLicenseScanner() (*licensecheck.Scanner, error) {
+	sharedScannerOnce.Do(func() {
+		sharedScanner, sharedScannerErr = licensecheck.NewScanner(licensecheck.BuiltinLicenses())
+	})
+	return sharedScanner, sharedScannerErr
+}
+
+// Scanner detects SPDX license IDs from license text on disk.
+type Scanner struct {
+	// CoverageThreshold overrides DefaultCoverageThreshold; zero means "use the
+	// default" rather than "accept any match".
+	CoverageThreshold float64
+	// MaxFileSize overrides DefaultMaxFileSize; zero means "use the default".
+	MaxFileSize int64
+}
+
+// NewScanner returns a Scanner configured with the default threshold and file size
+// guard.
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+func (s *Scanner) threshold() float64 {
+	if s.CoverageThreshold <= 0 {
+		return DefaultCoverageThreshold
+	}
+	return s.CoverageThreshold
+}
+
+func (s *Scanner) maxFileSize() int64 {
+	if s.MaxFileSize <= 0 {
+		return DefaultMaxFileSize
+	}
+	return s.MaxFileSize
+}
+
+// Detect resolves the license text in content to an SPDX ID, or UnknownLicense if no
+// match covers at least the configured coverage threshold.
+func (s *Scanner) Detect(content []byte) (string, error) {
+	scanner, err := sharedLicenseScanner()
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize license scanner: %w", err)
+	}
+
+	cov := scanner.Scan(content)
+	if len(cov.Match) == 0 {
+		return UnknownLicense, nil
+	}
+	best := cov.Match[0]
+	for _, m := range cov.Match[1:] {
+		if m.Percent > best.Percent {
+			best = m
+		}
+	}
+	if best.Percent/100.0 < s.threshold() {
+		return UnknownLicense, nil
+	}
+	return best.ID, nil
+}
+
+// ScanDir looks for a license file directly inside dir (candidateLicenseFiles, in
+// order) and returns its detected SPDX ID. It returns UnknownLicense, not an error,
+// when no license file is found or none is recognized -- that's an expected outcome
+// for a section with no committed license text, not a failure.
+func (s *Scanner) ScanDir(dir string) (string, error) {
+	for _, name := range candidateLicenseFiles {
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if info.Size() > s.maxFileSize() {
+			continue
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		return s.Detect(content)
+	}
+	return UnknownLicense, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package licensedetect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectUnknownForGibberish(t *testing.T) {
+	scanner := NewScanner()
+	id, err := scanner.Detect([]byte("this is definitely not a real license text"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, UnknownLicense, id)
+}
+
+func TestScanDirNoLicenseFile(t *testing.T) {
+	dir := t.TempDir()
+	scanner := NewScanner()
+	id, err := scanner.ScanDir(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, UnknownLicense, id)
+}
+
+func TestScanDirSkipsOversizedFile(t *testing.T) {
+	dir := t.TempDir()
+	scanner := &Scanner{MaxFileSize: 10}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "LICENSE"), []byte(strings.Repeat("x", 100)), 0644))
+	id, err := scanner.ScanDir(dir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, UnknownLicense, id)
+}
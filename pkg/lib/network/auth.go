@@ -17,6 +17,7 @@
 package network
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -33,8 +34,10 @@ import (
 	"oras.land/oras-go/v2/registry/remote/retry"
 )
 
-// NewCredentialStore returns a credential store from @storePath and falls back to Docker's native store for reads only.
-func NewCredentialStore(storePath string) (credentials.Store, error) {
+// NewCredentialStore returns a credential store from @storePath, consulting
+// registries' per-endpoint auth (if any) before it, and falls back to Docker's native
+// store for reads only. registries may be nil, in which case no override is applied.
+func NewCredentialStore(storePath string, registries *RegistriesConfig) (credentials.Store, error) {
 	existingCredStore, err := credentials.NewStore(storePath, credentials.StoreOptions{
 		DetectDefaultNativeStore: true,
 		AllowPlaintextPut:        true,
@@ -49,7 +52,34 @@ func NewCredentialStore(storePath string) (credentials.Store, error) {
 		return nil, err
 	}
 
-	return credentials.NewStoreWithFallbacks(existingCredStore, dockerCredStore), nil
+	registriesStore := registriesConfigStore{registries}
+	return credentials.NewStoreWithFallbacks(registriesStore, existingCredStore, dockerCredStore), nil
+}
+
+// registriesConfigStore is a read-only credentials.Store backed by a registries.yaml's
+// configs.*.auth entries, consulted before the on-disk credential stores.
+type registriesConfigStore struct {
+	registries *RegistriesConfig
+}
+
+func (s registriesConfigStore) Get(_ context.Context, serverAddress string) (auth.Credential, error) {
+	cfg, ok := s.registries.ConfigFor(serverAddress)
+	if !ok || cfg.Auth == nil {
+		return auth.EmptyCredential, nil
+	}
+	return auth.Credential{
+		Username:     cfg.Auth.Username,
+		Password:     cfg.Auth.Password,
+		RefreshToken: cfg.Auth.IdentityToken,
+	}, nil
+}
+
+func (s registriesConfigStore) Put(context.Context, string, auth.Credential) error {
+	return fmt.Errorf("credentials from registries.yaml are read-only")
+}
+
+func (s registriesConfigStore) Delete(context.Context, string) error {
+	return fmt.Errorf("credentials from registries.yaml are read-only")
 }
 
 // ClientWithAuth returns a default *auth.Client using the provided credentials
@@ -67,6 +97,18 @@ func ClientWithAuth(store credentials.Store, opts *options.NetworkOptions) (*aut
 // DefaultClient returns an *auth.Client with a default User-Agent header and TLS
 // configured from opts (optionally disabling TLS verification)
 func DefaultClient(opts *options.NetworkOptions) (*auth.Client, error) {
+	return newClient(opts, nil, "")
+}
+
+// DefaultClientForHost is like DefaultClient, but additionally overlays the TLS
+// settings from registries' EndpointConfig for host (if any), so a registries.yaml
+// entry for a pull-through cache or mirror can carry its own cert/verification
+// settings independent of the CLI-wide --tls-* flags.
+func DefaultClientForHost(opts *options.NetworkOptions, registries *RegistriesConfig, host string) (*auth.Client, error) {
+	return newClient(opts, registries, host)
+}
+
+func newClient(opts *options.NetworkOptions, registries *RegistriesConfig, host string) (*auth.Client, error) {
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig.InsecureSkipVerify = !opts.TLSVerify
 
@@ -100,6 +142,22 @@ func DefaultClient(opts *options.NetworkOptions) (*auth.Client, error) {
 		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, cert)
 	}
 
+	if host != "" {
+		// Only the TLS settings of the first endpoint, in Endpoints(host) order, that has
+		// a config entry are applied. The client does not itself send requests to mirror
+		// endpoints; it is used for whatever URLs the caller requests.
+		for _, endpoint := range registries.Endpoints(host) {
+			cfg, ok := registries.ConfigFor(endpoint)
+			if !ok {
+				continue
+			}
+			if err := applyEndpointTLS(transport, cfg.TLS); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
 	client := &auth.Client{
 		Client: &http.Client{
 			Transport: retry.NewTransport(transport),
@@ -113,6 +171,40 @@ func DefaultClient(opts *options.NetworkOptions) (*auth.Client, error) {
 	return client, nil
 }
 
+// applyEndpointTLS overlays an EndpointTLS's certificate/verification settings onto an
+// already-built transport, so a registries.yaml config entry for a specific mirror
+// endpoint can override the CLI-wide --tls-* flags for just that endpoint.
+func applyEndpointTLS(transport *http.Transport, tlsCfg *EndpointTLS) error {
+	if tlsCfg == nil {
+		return nil
+	}
+	if tlsCfg.InsecureSkipVerify {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	if tlsCfg.CAFile != "" {
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+		certsPEM, err := os.ReadFile(tlsCfg.CAFile)
+		if err != nil {
+			return fmt.Errorf("error reading certificate at path %s: %w", tlsCfg.CAFile, err)
+		}
+		if !certPool.AppendCertsFromPEM(certsPEM) {
+			return fmt.Errorf("failed to add certificate at path %s to pool", tlsCfg.CAFile)
+		}
+		transport.TLSClientConfig.RootCAs = certPool
+	}
+	if tlsCfg.CertFile != "" && tlsCfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read certificate: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, cert)
+	}
+	return nil
+}
+
 func getCertsTrust(opts *options.NetworkOptions) (*x509.CertPool, error) {
 	rootCAs, err := x509.SystemCertPool()
 	if err != nil {
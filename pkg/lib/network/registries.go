@@ -0,0 +1,109 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// RegistriesConfigFileName is the name of the per-registry configuration file,
+// modeled on k3s' registries.yaml, read from the CLI's config directory.
+const RegistriesConfigFileName = "registries.yaml"
+
+// RegistriesConfig holds per-registry settings: mirrors list alternate endpoints, in
+// preference order, for a given registry hostname, and configs carry auth/TLS/rewrite
+// rules for a specific endpoint. Of these, the auth entries (via NewCredentialStore) and
+// the TLS entries (via DefaultClientForHost) are applied. Nothing in this package sends
+// requests to mirror endpoints or falls back between them, and Rewrite rules are parsed
+// but not applied.
+type RegistriesConfig struct {
+	Mirrors map[string]MirrorConfig   `yaml:"mirrors"`
+	Configs map[string]EndpointConfig `yaml:"configs"`
+}
+
+// MirrorConfig lists the endpoints to try, in order, in place of a registry hostname.
+type MirrorConfig struct {
+	Endpoints []string `yaml:"endpoints"`
+}
+
+// EndpointConfig carries the auth, TLS, and rewrite rules for one registry endpoint.
+type EndpointConfig struct {
+	Auth    *EndpointAuth     `yaml:"auth,omitempty"`
+	TLS     *EndpointTLS      `yaml:"tls,omitempty"`
+	Rewrite map[string]string `yaml:"rewrite,omitempty"`
+}
+
+// EndpointAuth holds static credentials for an endpoint, as an alternative to the
+// Docker-style credential store.
+type EndpointAuth struct {
+	Username      string `yaml:"username,omitempty"`
+	Password      string `yaml:"password,omitempty"`
+	IdentityToken string `yaml:"identitytoken,omitempty"`
+}
+
+// EndpointTLS overrides the default TLS configuration for requests to one endpoint.
+type EndpointTLS struct {
+	CertFile           string `yaml:"cert_file,omitempty"`
+	KeyFile            string `yaml:"key_file,omitempty"`
+	CAFile             string `yaml:"ca_file,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// LoadRegistriesConfig reads RegistriesConfigFileName from configHome. A missing file
+// is not an error: it returns an empty, valid configuration so callers don't need to
+// special-case "no config present".
+func LoadRegistriesConfig(configHome string) (*RegistriesConfig, error) {
+	path := filepath.Join(configHome, RegistriesConfigFileName)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &RegistriesConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var cfg RegistriesConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Endpoints returns the endpoints to try for host, in preference order: any mirror
+// endpoints configured for host, followed by host itself as the final fallback.
+func (c *RegistriesConfig) Endpoints(host string) []string {
+	if c == nil {
+		return []string{host}
+	}
+	mirror, ok := c.Mirrors[host]
+	if !ok {
+		return []string{host}
+	}
+	return append(append([]string{}, mirror.Endpoints...), host)
+}
+
+// ConfigFor returns the EndpointConfig for endpoint, if one is configured.
+func (c *RegistriesConfig) ConfigFor(endpoint string) (EndpointConfig, bool) {
+	if c == nil {
+		return EndpointConfig{}, false
+	}
+	cfg, ok := c.Configs[endpoint]
+	return cfg, ok
+}
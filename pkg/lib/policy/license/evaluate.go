@@ -0,0 +1,128 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"fmt"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+)
+
+// Violation describes one Kitfile section that failed policy evaluation.
+type Violation struct {
+	Path    string
+	License string
+	Root    string
+	Reason  string
+}
+
+// Report is the aggregated result of evaluating a Kitfile against a Policy.
+type Report struct {
+	Violations []Violation
+}
+
+// HasViolations reports whether the report found anything.
+func (r *Report) HasViolations() bool {
+	return len(r.Violations) > 0
+}
+
+// section is a path+license pair pulled out of one part of the Kitfile, so Evaluate
+// can treat the model, model parts, datasets, and code entries uniformly.
+type section struct {
+	path    string
+	license string
+}
+
+// sections collects every path-bearing, policy-evaluable part of kitfile. The
+// top-level package license isn't included: it describes the ModelKit as a whole
+// rather than a path inside it, so there's nothing for a root-scoped Rule to match.
+func sections(kitfile *artifact.KitFile) []section {
+	var out []section
+	if kitfile.Model != nil && kitfile.Model.Path != "" {
+		out = append(out, section{path: kitfile.Model.Path, license: kitfile.Model.License})
+		for _, part := range kitfile.Model.Parts {
+			if part.Path != "" {
+				out = append(out, section{path: part.Path, license: part.License})
+			}
+		}
+	}
+	for _, ds := range kitfile.DataSets {
+		if ds.Path != "" {
+			out = append(out, section{path: ds.Path, license: ds.License})
+		}
+	}
+	for _, c := range kitfile.Code {
+		if c.Path != "" {
+			out = append(out, section{path: c.Path, license: c.License})
+		}
+	}
+	return out
+}
+
+// Evaluate checks every section of kitfile against policy, returning a Report of
+// every violation found. A section with no matching Rule is not enforced at all.
+func Evaluate(kitfile *artifact.KitFile, policy *Policy) (*Report, error) {
+	if kitfile == nil {
+		return nil, fmt.Errorf("cannot evaluate license policy: kitfile is nil")
+	}
+	if policy == nil {
+		return nil, fmt.Errorf("cannot evaluate license policy: policy is nil")
+	}
+
+	report := &Report{}
+	for _, s := range sections(kitfile) {
+		rule := policy.ruleForPath(s.path)
+		if rule == nil {
+			continue
+		}
+
+		if s.license == "" {
+			if rule.Required {
+				report.Violations = append(report.Violations, Violation{
+					Path:   s.path,
+					Root:   rule.Root,
+					Reason: "no license declared, but policy requires one",
+				})
+			}
+			continue
+		}
+
+		expr, err := ParseExpression(s.license)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", s.path, err)
+		}
+		for _, id := range expr.IDs {
+			switch {
+			case containsFold(rule.Deny, id):
+				report.Violations = append(report.Violations, Violation{
+					Path:    s.path,
+					License: id,
+					Root:    rule.Root,
+					Reason:  "license is explicitly denied",
+				})
+			case len(rule.Allow) > 0 && !containsFold(rule.Allow, id):
+				report.Violations = append(report.Violations, Violation{
+					Path:    s.path,
+					License: id,
+					Root:    rule.Root,
+					Reason:  "license is not in the allow list",
+				})
+			}
+		}
+	}
+	return report, nil
+}
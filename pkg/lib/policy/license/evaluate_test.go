@@ -0,0 +1,116 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kitops-ml/kitops/pkg/artifact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	raw := `
+rules:
+  - root: model/
+    allow: ["Apache-2.0", "MIT"]
+  - root: datasets/
+    deny: ["GPL-3.0-only"]
+    required: true
+`
+	policy, err := LoadPolicy(strings.NewReader(raw))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, policy.Rules, 2)
+	assert.True(t, policy.Rules[1].Required)
+}
+
+func TestLoadPolicyRejectsMissingRoot(t *testing.T) {
+	_, err := LoadPolicy(strings.NewReader("rules:\n  - allow: [\"MIT\"]\n"))
+	assert.ErrorContains(t, err, "root path")
+}
+
+func TestEvaluateDeniedLicense(t *testing.T) {
+	policy := &Policy{Rules: []Rule{{Root: "model/", Deny: []string{"GPL-3.0-only"}}}}
+	kitfile := &artifact.KitFile{
+		Model: &artifact.Model{Path: "model/", License: "GPL-3.0-only"},
+	}
+	report, err := Evaluate(kitfile, policy)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, report.Violations, 1) {
+		return
+	}
+	assert.Equal(t, "license is explicitly denied", report.Violations[0].Reason)
+}
+
+func TestEvaluateAllowListRejectsUnlistedLicense(t *testing.T) {
+	policy := &Policy{Rules: []Rule{{Root: "code/", Allow: []string{"Apache-2.0"}}}}
+	kitfile := &artifact.KitFile{
+		Code: []artifact.Code{{Path: "code/", License: "MIT"}},
+	}
+	report, err := Evaluate(kitfile, policy)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, report.Violations, 1)
+}
+
+func TestEvaluateRequiredLicenseMissing(t *testing.T) {
+	policy := &Policy{Rules: []Rule{{Root: "datasets/", Required: true}}}
+	kitfile := &artifact.KitFile{
+		DataSets: []artifact.DataSet{{Path: "datasets/"}},
+	}
+	report, err := Evaluate(kitfile, policy)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, report.Violations, 1) {
+		return
+	}
+	assert.Contains(t, report.Violations[0].Reason, "requires one")
+}
+
+func TestEvaluateMostSpecificRuleWins(t *testing.T) {
+	policy := &Policy{Rules: []Rule{
+		{Root: "code/", Allow: []string{"Apache-2.0"}},
+		{Root: "code/extra/", Allow: []string{"MIT"}},
+	}}
+	kitfile := &artifact.KitFile{
+		Code: []artifact.Code{{Path: "code/extra/", License: "MIT"}},
+	}
+	report, err := Evaluate(kitfile, policy)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, report.HasViolations())
+}
+
+func TestEvaluateNoRuleMeansNoEnforcement(t *testing.T) {
+	policy := &Policy{Rules: []Rule{{Root: "model/", Deny: []string{"MIT"}}}}
+	kitfile := &artifact.KitFile{
+		Code: []artifact.Code{{Path: "code/", License: "AnythingGoes"}},
+	}
+	report, err := Evaluate(kitfile, policy)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, report.HasViolations())
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expression is a parsed SPDX license expression (e.g. "Apache-2.0 OR MIT", or
+// "GPL-2.0-only WITH Classpath-exception-2.0"). Evaluate only needs the set of
+// license IDs an expression references, not the full AND/OR/WITH boolean structure,
+// since a Rule's allow/deny lists are themselves just sets of IDs: every ID in an
+// expression is checked against the rule independently, regardless of how the
+// expression combines them.
+type Expression struct {
+	Raw string
+	IDs []string
+}
+
+var expressionOperators = map[string]bool{"AND": true, "OR": true, "WITH": true}
+
+// ParseExpression splits raw into its component license IDs, stripping the AND/OR/WITH
+// operators and any parentheses. It does not validate IDs against the SPDX license
+// list -- there's no embedded copy of that list in this tree -- so unrecognized IDs
+// are accepted as-is and rejected later only if a Rule's allow/deny lists say so.
+func ParseExpression(raw string) (*Expression, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return nil, fmt.Errorf("empty license expression")
+	}
+
+	replacer := strings.NewReplacer("(", " ", ")", " ")
+	var ids []string
+	for _, token := range strings.Fields(replacer.Replace(trimmed)) {
+		if expressionOperators[strings.ToUpper(token)] {
+			continue
+		}
+		ids = append(ids, token)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("license expression %q has no license IDs", raw)
+	}
+	return &Expression{Raw: raw, IDs: ids}, nil
+}
+
+// containsFold reports whether id appears in ids, case-insensitively -- SPDX IDs are
+// conventionally mixed-case (e.g. "Apache-2.0") but policy authors shouldn't have to
+// match that exactly.
+func containsFold(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if strings.EqualFold(candidate, id) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpressionSimple(t *testing.T) {
+	expr, err := ParseExpression("Apache-2.0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"Apache-2.0"}, expr.IDs)
+}
+
+func TestParseExpressionOrAnd(t *testing.T) {
+	expr, err := ParseExpression("(Apache-2.0 OR MIT) AND BSD-3-Clause")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"Apache-2.0", "MIT", "BSD-3-Clause"}, expr.IDs)
+}
+
+func TestParseExpressionWith(t *testing.T) {
+	expr, err := ParseExpression("GPL-2.0-only WITH Classpath-exception-2.0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"GPL-2.0-only", "Classpath-exception-2.0"}, expr.IDs)
+}
+
+func TestParseExpressionEmpty(t *testing.T) {
+	_, err := ParseExpression("   ")
+	assert.Error(t, err)
+}
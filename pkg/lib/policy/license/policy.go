@@ -0,0 +1,93 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package license enforces per-path license allow/deny rules against a Kitfile,
+// for orgs that mix permissively- and restrictively-licensed artifacts inside one
+// ModelKit.
+package license
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Policy is a license policy file: a list of Rules, each scoped to a root path in
+// the ModelKit (e.g. "model/", "datasets/", "code/extra/").
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Rule constrains the licenses permitted under Root. Allow and Deny hold SPDX
+// license IDs, each compared case-insensitively and as a whole against every ID in a
+// section's license expression (an entry that is itself an expression matches only an
+// ID spelled exactly like it); Deny always wins over Allow when an
+// ID somehow appears in both. An empty Allow list means "anything not denied is
+// fine" -- Allow only becomes an allowlist once it's non-empty.
+type Rule struct {
+	Root     string   `yaml:"root"`
+	Allow    []string `yaml:"allow,omitempty"`
+	Deny     []string `yaml:"deny,omitempty"`
+	Required bool     `yaml:"required,omitempty"`
+}
+
+// LoadPolicy parses a YAML policy file.
+func LoadPolicy(r io.Reader) (*Policy, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var p Policy
+	if err := yaml.Unmarshal(raw, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	for _, rule := range p.Rules {
+		if rule.Root == "" {
+			return nil, fmt.Errorf("policy rule is missing a root path")
+		}
+	}
+	return &p, nil
+}
+
+// ruleForPath returns the rule whose Root is the longest matching prefix of path, or
+// nil if no rule applies. Longest-prefix-wins lets a narrower rule (e.g.
+// "code/extra/") override a broader one (e.g. "code/") for the paths it covers.
+func (p *Policy) ruleForPath(path string) *Rule {
+	var best *Rule
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !isUnderRoot(path, rule.Root) {
+			continue
+		}
+		if best == nil || len(rule.Root) > len(best.Root) {
+			best = rule
+		}
+	}
+	return best
+}
+
+// isUnderRoot reports whether path is root itself or nested under it, treating both
+// as slash-separated paths regardless of trailing slashes.
+func isUnderRoot(path, root string) bool {
+	path = strings.TrimSuffix(path, "/")
+	root = strings.TrimSuffix(root, "/")
+	if root == "" {
+		return true
+	}
+	return path == root || strings.HasPrefix(path, root+"/")
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+// Authenticator supplies the Authorization header for chunked upload requests and knows
+// how to refresh it once the registry signals, via a 401 and a WWW-Authenticate
+// challenge, that the credentials it started with have expired.
+type Authenticator interface {
+	// Header returns the current Authorization header value, or "" if none is set.
+	Header() string
+	// Refresh exchanges wwwAuthenticate (the WWW-Authenticate header from a 401
+	// response) for a fresh token, updating what Header returns. It returns an error if
+	// wwwAuthenticate can't be satisfied, e.g. it isn't a Bearer challenge.
+	Refresh(ctx context.Context, client remote.Client, wwwAuthenticate string) error
+}
+
+// bearerChallengeParam matches a single key="value" pair inside a WWW-Authenticate:
+// Bearer ... header, e.g. realm="https://auth.example.com/token".
+var bearerChallengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// ChallengeManager is an Authenticator that starts from a captured Authorization header
+// (typically the one used to initiate the upload session) and, once the registry issues
+// a Bearer challenge, exchanges it for a fresh token from the challenge's realm. It's a
+// much smaller relative of docker/distribution's registry/client/auth/challenge
+// package: kitops only ever needs to refresh the single scope a chunked upload already
+// holds, not manage a cache of challenges across many repositories.
+type ChallengeManager struct {
+	mu     sync.Mutex
+	header string
+}
+
+// NewChallengeManager returns a ChallengeManager starting from initialHeader, the
+// Authorization header used to initiate the upload session.
+func NewChallengeManager(initialHeader string) *ChallengeManager {
+	return &ChallengeManager{header: initialHeader}
+}
+
+func (c *ChallengeManager) Header() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.header
+}
+
+func (c *ChallengeManager) Refresh(ctx context.Context, client remote.Client, wwwAuthenticate string) error {
+	realm, service, scope, err := parseBearerChallenge(wwwAuthenticate)
+	if err != nil {
+		return err
+	}
+
+	tokenUrl, err := url.Parse(realm)
+	if err != nil {
+		return fmt.Errorf("invalid realm in bearer challenge: %w", err)
+	}
+	q := tokenUrl.Query()
+	if service != "" {
+		q.Set("service", service)
+	}
+	if scope != "" {
+		q.Set("scope", scope)
+	}
+	tokenUrl.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenUrl.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch refreshed token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token endpoint returned unexpected status: %s", resp.Status)
+	}
+
+	// Per the distribution spec, the token endpoint may use either field name.
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return fmt.Errorf("failed to parse token response: %w", err)
+	}
+	token := tokenResp.Token
+	if token == "" {
+		token = tokenResp.AccessToken
+	}
+	if token == "" {
+		return fmt.Errorf("token endpoint response did not include a token")
+	}
+
+	c.mu.Lock()
+	c.header = "Bearer " + token
+	c.mu.Unlock()
+	return nil
+}
+
+// parseBearerChallenge parses a WWW-Authenticate header of the form
+// `Bearer realm="...",service="...",scope="..."`, returning its realm, service, and
+// scope parameters. service and scope are optional and returned empty if absent; realm
+// is required.
+func parseBearerChallenge(wwwAuthenticate string) (realm, service, scope string, err error) {
+	if !strings.HasPrefix(wwwAuthenticate, "Bearer ") {
+		return "", "", "", fmt.Errorf("cannot refresh credentials: unsupported challenge %q", wwwAuthenticate)
+	}
+	params := map[string]string{}
+	for _, match := range bearerChallengeParam.FindAllStringSubmatch(wwwAuthenticate, -1) {
+		params[match[1]] = match[2]
+	}
+	realm, ok := params["realm"]
+	if !ok {
+		return "", "", "", fmt.Errorf("bearer challenge missing realm: %q", wwwAuthenticate)
+	}
+	return realm, params["service"], params["scope"], nil
+}
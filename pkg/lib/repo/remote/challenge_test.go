@@ -0,0 +1,77 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBearerChallenge(t *testing.T) {
+	realm, service, scope, err := parseBearerChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo:pull,push"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://auth.example.com/token", realm)
+	assert.Equal(t, "registry.example.com", service)
+	assert.Equal(t, "repository:foo:pull,push", scope)
+}
+
+func TestParseBearerChallengeMissingRealm(t *testing.T) {
+	_, _, _, err := parseBearerChallenge(`Bearer service="registry.example.com"`)
+	assert.Error(t, err)
+}
+
+func TestParseBearerChallengeNotBearer(t *testing.T) {
+	_, _, _, err := parseBearerChallenge(`Basic realm="registry.example.com"`)
+	assert.Error(t, err)
+}
+
+func TestChallengeManagerRefresh(t *testing.T) {
+	tc := &testClient{
+		responses: []func(*http.Request) (*http.Response, error){
+			func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "/token", req.URL.Path)
+				assert.Equal(t, "registry.example.com", req.URL.Query().Get("service"))
+				assert.Equal(t, "repository:foo:pull", req.URL.Query().Get("scope"))
+				return makeResponse(req, http.StatusOK, `{"token":"abc123"}`, nil)
+			},
+		},
+	}
+
+	cm := NewChallengeManager("stale")
+	assert.Equal(t, "stale", cm.Header())
+
+	err := cm.Refresh(t.Context(), tc, `Bearer realm="http://127.0.0.1/token",service="registry.example.com",scope="repository:foo:pull"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer abc123", cm.Header())
+}
+
+func TestChallengeManagerRefreshAccessTokenField(t *testing.T) {
+	tc := &testClient{
+		responses: []func(*http.Request) (*http.Response, error){
+			func(req *http.Request) (*http.Response, error) {
+				return makeResponse(req, http.StatusOK, `{"access_token":"xyz789"}`, nil)
+			},
+		},
+	}
+
+	cm := NewChallengeManager("")
+	err := cm.Refresh(t.Context(), tc, `Bearer realm="http://127.0.0.1/token"`)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer xyz789", cm.Header())
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
+
+	"github.com/containers/ocicrypt"
+	encconfig "github.com/containers/ocicrypt/config"
+	"github.com/containers/ocicrypt/helpers"
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// EncryptionConfig configures layer encryption for a Repository, modeled on the
+// containers/image copy pipeline. Recipients are ocicrypt key-spec strings (e.g.
+// "jwe:<pubkey-file>", "pgp:<key-id-or-email>", "pkcs7:<cert-file>") used when pushing a
+// layer through EncryptLayer; DecryptionKeyPaths are PEM-encoded private key files used
+// when pulling a layer that carries mediatype.EncryptedSuffix. A Repository whose
+// Encryption is nil has encryption disabled entirely: EncryptLayer refuses to run and
+// Fetch passes ciphertext through unchanged. A zero-value EncryptionConfig also makes
+// EncryptLayer refuse to run, but Fetch then fails on an encrypted layer, since no
+// decryption keys are configured.
+type EncryptionConfig struct {
+	Recipients         []string
+	DecryptionKeyPaths []string
+}
+
+// LayerEncrypter produces ciphertext (and the descriptor matching it) for a plaintext
+// layer. Implemented by *ocicryptLayerCrypter; exists as an interface so Push-adjacent
+// code can be tested against a fake without invoking real ocicrypt.
+type LayerEncrypter interface {
+	// Encrypt reads all of plain, encrypts it for the configured recipients, and
+	// returns the ciphertext alongside a descriptor with an updated digest, size, and
+	// media type (desc.MediaType plus mediatype.EncryptedSuffix). The returned reader
+	// is a bytes.Reader so callers can feed it straight into the existing
+	// chunked/monolithic upload paths, which already expect seekable content for
+	// retries.
+	Encrypt(plain io.Reader, desc ocispec.Descriptor) (io.ReadSeeker, ocispec.Descriptor, error)
+}
+
+// LayerDecrypter transparently decrypts a layer fetched from the registry. Implemented
+// by *ocicryptLayerCrypter.
+type LayerDecrypter interface {
+	// Decrypt wraps rc in a stream that yields desc's plaintext, resolving the
+	// symmetric layer key from desc's org.opencontainers.image.enc.* annotations using
+	// the configured decryption keys. Layers that aren't encrypted are returned
+	// unchanged; decryption itself is fully streaming, same as ocicrypt.DecryptLayer.
+	Decrypt(rc io.ReadCloser, desc ocispec.Descriptor) (io.ReadCloser, error)
+}
+
+// ocicryptLayerCrypter is the ocicrypt-backed implementation of LayerEncrypter and
+// LayerDecrypter. cc is built once from EncryptionConfig so Encrypt/Decrypt don't
+// re-parse recipients or re-read key files on every call.
+type ocicryptLayerCrypter struct {
+	cc encconfig.CryptoConfig
+}
+
+// NewLayerCrypter builds a LayerEncrypter/LayerDecrypter from cfg. It's valid to call
+// with only one side populated (e.g. DecryptionKeyPaths with no Recipients, for a
+// puller that never pushes encrypted content); Encrypt or Decrypt then simply fails if
+// called without the config it needs.
+func NewLayerCrypter(cfg EncryptionConfig) (*ocicryptLayerCrypter, error) {
+	cc, err := helpers.CreateCryptoConfig(cfg.Recipients, cfg.DecryptionKeyPaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure layer encryption: %w", err)
+	}
+	return &ocicryptLayerCrypter{cc: cc}, nil
+}
+
+func (c *ocicryptLayerCrypter) Encrypt(plain io.Reader, desc ocispec.Descriptor) (io.ReadSeeker, ocispec.Descriptor, error) {
+	if c.cc.EncryptConfig == nil {
+		return nil, ocispec.Descriptor{}, fmt.Errorf("layer %s cannot be encrypted: no recipients configured", desc.Digest)
+	}
+	encR, newDesc, err := ocicrypt.EncryptLayer(c.cc.EncryptConfig, plain, desc)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, fmt.Errorf("failed to encrypt layer %s: %w", desc.Digest, err)
+	}
+	// The chunked/monolithic upload paths need a final digest and size up front (the
+	// digest is sent as a query param on the completing PUT, and Content-Length is set
+	// from Size), so unlike Decrypt below, this can't stay streaming all the way to the
+	// wire: the whole ciphertext is read into memory here. Recompute the digest/size
+	// ourselves from what was actually produced rather than trusting newDesc's copies,
+	// so a mismatch in ocicrypt's own bookkeeping can never produce a blob that doesn't
+	// match the digest we tell the registry to expect.
+	ciphertext, err := io.ReadAll(encR)
+	if err != nil {
+		return nil, ocispec.Descriptor{}, fmt.Errorf("failed to encrypt layer %s: %w", desc.Digest, err)
+	}
+	newDesc.Digest = digest.FromBytes(ciphertext)
+	newDesc.Size = int64(len(ciphertext))
+	newDesc.MediaType = desc.MediaType + mediatype.EncryptedSuffix
+	return bytes.NewReader(ciphertext), newDesc, nil
+}
+
+func (c *ocicryptLayerCrypter) Decrypt(rc io.ReadCloser, desc ocispec.Descriptor) (io.ReadCloser, error) {
+	if !mediatype.IsEncryptedMediaTypeString(desc.MediaType) {
+		return rc, nil
+	}
+	if c.cc.DecryptConfig == nil {
+		return nil, fmt.Errorf("layer %s is encrypted but no decryption keys were configured", desc.Digest)
+	}
+	plain, _, err := ocicrypt.DecryptLayer(c.cc.DecryptConfig, rc, desc, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt layer %s: %w", desc.Digest, err)
+	}
+	return io.NopCloser(plain), nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2/registry"
+)
+
+func TestFetchFromPartialContent(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	content := []byte("full blob content")
+	desc := ocispec.Descriptor{Digest: digest.FromBytes(content), Size: int64(len(content))}
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodGet, "/v2/testrepo/blobs/"+desc.Digest.String()); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "bytes=5-", req.Header.Get("Range"))
+			return makeResponse(req, http.StatusPartialContent, string(content[5:]), nil)
+		},
+	}
+	tc := &testClient{responses: responses}
+	testRepo := Repository{
+		Repository: nil,
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	rc, err := testRepo.FetchFrom(t.Context(), desc, 5)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if assert.NoError(t, err) {
+		assert.Equal(t, content[5:], got)
+	}
+}
+
+func TestFetchFromIgnoredRangeSkipsAhead(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	content := []byte("full blob content")
+	desc := ocispec.Descriptor{Digest: digest.FromBytes(content), Size: int64(len(content))}
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodGet, "/v2/testrepo/blobs/"+desc.Digest.String()); err != nil {
+				return nil, err
+			}
+			// Registry doesn't honor Range and sends the whole blob back.
+			return makeResponse(req, http.StatusOK, string(content), nil)
+		},
+	}
+	tc := &testClient{responses: responses}
+	testRepo := Repository{
+		Repository: nil,
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	rc, err := testRepo.FetchFrom(t.Context(), desc, 5)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if assert.NoError(t, err) {
+		assert.Equal(t, content[5:], got)
+	}
+}
+
+func TestFetchFromRejectedOffset(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	content := []byte("full blob content")
+	desc := ocispec.Descriptor{Digest: digest.FromBytes(content), Size: int64(len(content))}
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			return makeResponse(req, http.StatusRequestedRangeNotSatisfiable, "", nil)
+		},
+	}
+	tc := &testClient{responses: responses}
+	testRepo := Repository{
+		Repository: nil,
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	_, err := testRepo.FetchFrom(t.Context(), desc, 5)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	assert.ErrorIs(t, err, errResumeOffsetInvalid)
+}
+
+func TestFetchFromOffsetPastEndReturnsEmptyReader(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	desc := ocispec.Descriptor{Digest: ocispec.DescriptorEmptyJSON.Digest, Size: 2}
+	testRepo := Repository{
+		Repository: nil,
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     &testClient{responses: nil},
+	}
+
+	rc, err := testRepo.FetchFrom(t.Context(), desc, desc.Size)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if assert.NoError(t, err) {
+		assert.Empty(t, got)
+	}
+}
+
+func TestFetchFromEncryptedLayerRejected(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	desc := ocispec.Descriptor{
+		Digest:    ocispec.DescriptorEmptyJSON.Digest,
+		Size:      100,
+		MediaType: "application/vnd.kitops.modelkit.model.v1.tar+encrypted",
+	}
+	testRepo := Repository{
+		Repository: nil,
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     &testClient{responses: nil},
+	}
+
+	_, err := testRepo.FetchFrom(t.Context(), desc, 10)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/kitops-ml/kitops/pkg/output"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// uploadBlobParallel is an opt-in alternative to uploadBlobChunked for random-access
+// content: rather than waiting for each PATCH's response before sending the next, it
+// issues up to concurrency PATCHes at once, each against its own absolute byte range,
+// all targeting the upload session's starting URL. This assumes the registry accepts
+// chunks out of order, which the distribution spec does not guarantee; a registry that
+// rejects a non-contiguous range (416 or 400) causes a one-time downgrade to the
+// existing sequential uploadBlobChunkedFrom path, which no registry implementing the
+// spec can reject.
+//
+// A shared, mutex-guarded "confirmed" watermark tracks the highest byte offset that has
+// been acknowledged across all in-flight chunks; it only ever advances, and a failed
+// chunk is retried (via uploadBlobChunkWithRetry, same as the sequential path) without
+// disturbing the ranges other goroutines are working on. The final PUT is only sent
+// once every range has been confirmed.
+func (r *Repository) uploadBlobParallel(ctx context.Context, startURL *url.URL, authn Authenticator, expected ocispec.Descriptor, content io.ReaderAt, concurrency int) (string, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	chunkSize := r.uploadChunkSize
+	if chunkSize <= 0 {
+		chunkSize = expected.Size
+	}
+
+	var ranges []parallelRange
+	for start := int64(0); start < expected.Size; start += chunkSize {
+		end := min(start+chunkSize-1, expected.Size-1)
+		ranges = append(ranges, parallelRange{start: start, end: end})
+	}
+
+	upload, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan parallelResult, len(ranges))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, rng := range ranges {
+		wg.Add(1)
+		go func(rng parallelRange) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results <- r.uploadParallelChunk(upload, startURL, authn, expected, content, rng)
+		}(rng)
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		mu            sync.Mutex
+		confirmed     int64
+		finalLocation *url.URL
+		firstErr      error
+		downgrade     bool
+	)
+	for res := range results {
+		mu.Lock()
+		switch {
+		case res.downgrade:
+			downgrade = true
+			cancel()
+		case res.err != nil:
+			if firstErr == nil {
+				firstErr = res.err
+			}
+			cancel()
+		default:
+			if res.rng.end+1 > confirmed {
+				confirmed = res.rng.end + 1
+			}
+			finalLocation = res.location
+		}
+		mu.Unlock()
+	}
+
+	if downgrade {
+		output.SafeDebugf("[%s] Registry rejected a non-contiguous chunk, falling back to sequential upload", expected.Digest.Encoded()[0:8])
+		return r.uploadBlobChunkedFrom(ctx, r.uploadKey(expected), startURL, authn.Header(), expected, content, 0, r.uploadChunkSize)
+	}
+	if firstErr != nil {
+		return "", fmt.Errorf("failed to upload blob chunk: %w", firstErr)
+	}
+	if confirmed != expected.Size {
+		return "", fmt.Errorf("parallel upload only confirmed %d of %d bytes", confirmed, expected.Size)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, finalLocation.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	q := req.URL.Query()
+	q.Set("digest", expected.Digest.String())
+	req.URL.RawQuery = q.Encode()
+	if h := authn.Header(); h != "" {
+		req.Header.Set("Authorization", h)
+	}
+
+	output.SafeDebugf("[%s] Finalizing parallel upload", expected.Digest.Encoded()[0:8])
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to finalize blob upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", handleRemoteError(resp)
+	}
+
+	blobLocation, err := resp.Location()
+	if err != nil {
+		output.Errorf("Warning: remote registry did not return blob location")
+	}
+	return blobLocation.String(), nil
+}
+
+// parallelRange is an inclusive byte range uploaded by a single uploadBlobParallel
+// goroutine.
+type parallelRange struct {
+	start, end int64
+}
+
+// parallelResult is what each uploadBlobParallel goroutine reports back for its range:
+// either the Location it was acknowledged at, a hard error, or downgrade set to signal
+// the registry rejected the range outright and the whole upload should fall back to the
+// sequential path.
+type parallelResult struct {
+	rng       parallelRange
+	location  *url.URL
+	err       error
+	downgrade bool
+}
+
+// uploadParallelChunk uploads a single range of uploadBlobParallel's split, retrying
+// transient failures the same way the sequential path does (via
+// uploadBlobChunkWithRetry).
+func (r *Repository) uploadParallelChunk(ctx context.Context, startURL *url.URL, authn Authenticator, expected ocispec.Descriptor, content io.ReaderAt, rng parallelRange) parallelResult {
+	section := io.NewSectionReader(content, rng.start, rng.end-rng.start+1)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, startURL.String(), nil)
+	if err != nil {
+		return parallelResult{rng: rng, err: err}
+	}
+	req.ContentLength = rng.end - rng.start + 1
+	req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", rng.start, rng.end))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if h := authn.Header(); h != "" {
+		req.Header.Set("Authorization", h)
+	}
+
+	output.SafeDebugf("[%s] Uploading chunk range %d-%d in parallel", expected.Digest.Encoded()[0:8], rng.start, rng.end)
+	resp, err := r.uploadBlobChunkWithRetry(ctx, req, section, expected, 0, rng.end-rng.start)
+	if err != nil {
+		return parallelResult{rng: rng, err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return parallelResult{rng: rng, downgrade: true}
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return parallelResult{rng: rng, err: handleRemoteError(resp)}
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return parallelResult{rng: rng, err: fmt.Errorf("missing Location header in response")}
+	}
+	return parallelResult{rng: rng, location: location}
+}
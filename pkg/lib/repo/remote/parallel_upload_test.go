@@ -0,0 +1,326 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2/registry"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// concurrentTestClient is testClient's counterpart for uploadBlobParallel: several
+// goroutines call Do at once, so a positional response queue (as testClient uses)
+// would race and can't express "any of these requests may arrive first". handler is
+// responsible for its own synchronization; Do itself is just a passthrough.
+type concurrentTestClient struct {
+	handler func(req *http.Request) (*http.Response, error)
+}
+
+func (c *concurrentTestClient) Do(req *http.Request) (*http.Response, error) {
+	return c.handler(req)
+}
+
+func randomBytes(t testing.TB, size int64) []byte {
+	t.Helper()
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+func TestUploadBlobParallelSuccess(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testChunkSize int64 = 10
+	expectedSize := 3 * testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	content := bytes.NewReader(randomBytes(t, expectedSize))
+
+	var mu sync.Mutex
+	seenRanges := map[string]bool{}
+	puts := 0
+
+	tc := &concurrentTestClient{
+		handler: func(req *http.Request) (*http.Response, error) {
+			switch req.Method {
+			case http.MethodPatch:
+				if err := processRequest(req, http.MethodPatch, "/session"); err != nil {
+					return nil, err
+				}
+				mu.Lock()
+				seenRanges[req.Header.Get("Content-Range")] = true
+				mu.Unlock()
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/session",
+				})
+			case http.MethodPut:
+				if err := processRequest(req, http.MethodPut, "/session"); err != nil {
+					return nil, err
+				}
+				assert.Equal(t, expectedDigest.String(), req.URL.Query().Get("digest"))
+				mu.Lock()
+				puts++
+				mu.Unlock()
+				return makeResponse(req, http.StatusCreated, "", map[string]string{
+					"Location": "finalLocation",
+				})
+			default:
+				return nil, fmt.Errorf("unexpected method %s", req.Method)
+			}
+		},
+	}
+
+	testRepo := Repository{
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobParallel(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, content, 3)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation)
+	assert.Equal(t, 1, puts, "final PUT should only be sent once, after every range is confirmed")
+	assert.Equal(t, map[string]bool{"0-9": true, "10-19": true, "20-29": true}, seenRanges,
+		"every byte range should be uploaded exactly once, regardless of which goroutine's PATCH the fake registry saw first")
+}
+
+// TestUploadBlobParallelOutOfOrderResponses holds back the response to the first
+// range's PATCH until the other two ranges have already been acknowledged, simulating
+// a registry (or just unlucky scheduling) that confirms chunks out of order.
+// uploadBlobParallel collects every range's result before it sends the final PUT, so
+// the PUT must still wait on the first range rather than firing as soon as a
+// higher-offset range responds.
+func TestUploadBlobParallelOutOfOrderResponses(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testChunkSize int64 = 10
+	expectedSize := 3 * testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	content := bytes.NewReader(randomBytes(t, expectedSize))
+
+	holdFirstRange := make(chan struct{})
+	var laterRangesSeen int32
+
+	tc := &concurrentTestClient{
+		handler: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPut {
+				if err := processRequest(req, http.MethodPut, "/session"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "finalLocation"})
+			}
+			if err := processRequest(req, http.MethodPatch, "/session"); err != nil {
+				return nil, err
+			}
+			if req.Header.Get("Content-Range") == "0-9" {
+				<-holdFirstRange
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{"Location": "/session"})
+			}
+			if atomic.AddInt32(&laterRangesSeen, 1) == 2 {
+				close(holdFirstRange)
+			}
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{"Location": "/session"})
+		},
+	}
+
+	testRepo := Repository{
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobParallel(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, content, 3)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation)
+}
+
+// TestUploadBlobParallelDowngradesOnRejectedRange checks that a registry rejecting an
+// out-of-order PATCH (416, per the distribution spec's response to a non-contiguous
+// range) causes a one-time downgrade to the sequential path rather than a hard
+// failure.
+func TestUploadBlobParallelDowngradesOnRejectedRange(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSize := int64(20)
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	content := bytes.NewReader(randomBytes(t, expectedSize))
+
+	var mu sync.Mutex
+	sequentialStarted := false
+
+	tc := &concurrentTestClient{
+		handler: func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case req.Method == http.MethodPatch && !sequentialStarted:
+				if err := processRequest(req, http.MethodPatch, "/session"); err != nil {
+					return nil, err
+				}
+				sequentialStarted = true
+				// The registry rejects the chunk outright, as it's allowed to for a
+				// non-contiguous range.
+				return makeResponse(req, http.StatusRequestedRangeNotSatisfiable, "", nil)
+			case req.Method == http.MethodPatch:
+				if err := processRequest(req, http.MethodPatch, "/session"); err != nil {
+					return nil, err
+				}
+				assert.Equal(t, "0-19", req.Header.Get("Content-Range"), "sequential fallback should restart from byte zero")
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/session",
+					"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+				})
+			default:
+				if err := processRequest(req, http.MethodPut, "/session"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "finalLocation"})
+			}
+		},
+	}
+
+	testRepo := Repository{
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: expectedSize, // a single range, to keep the downgrade deterministic
+		CacheDir:        t.TempDir(),
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobParallel(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, content, 2)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation)
+	assert.True(t, sequentialStarted)
+}
+
+func BenchmarkUploadBlobParallel(b *testing.B) {
+	startUrl, err := url.Parse("http://127.0.0.1/session")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const testChunkSize int64 = 1 << 20 // 1MiB
+	const expectedSize = 16 * testChunkSize
+	expectedDesc := ocispec.Descriptor{Digest: ocispec.DescriptorEmptyJSON.Digest, Size: expectedSize}
+	content := bytes.NewReader(make([]byte, expectedSize))
+
+	tc := &concurrentTestClient{
+		handler: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPut {
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "finalLocation"})
+			}
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{"Location": "/session"})
+		},
+	}
+	testRepo := Repository{
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := testRepo.uploadBlobParallel(b.Context(), startUrl, NewChallengeManager(""), expectedDesc, content, 8); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkUploadBlobChunkedSequential is the sequential baseline uploadBlobParallel is
+// meant to improve on, run against the same fake registry so the two numbers are
+// comparable.
+func BenchmarkUploadBlobChunkedSequential(b *testing.B) {
+	startUrl, err := url.Parse("http://127.0.0.1/session")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	const testChunkSize int64 = 1 << 20 // 1MiB
+	const expectedSize = 16 * testChunkSize
+	expectedDesc := ocispec.Descriptor{Digest: ocispec.DescriptorEmptyJSON.Digest, Size: expectedSize}
+	content := bytes.NewReader(make([]byte, expectedSize))
+
+	tc := &concurrentTestClient{
+		handler: func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodPut {
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "finalLocation"})
+			}
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{"Location": "/session"})
+		},
+	}
+	testRepo := Repository{
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := testRepo.uploadBlobChunked(b.Context(), startUrl, NewChallengeManager(""), expectedDesc, content, 0); err != nil {
+			b.Fatal(err)
+		}
+		content.Seek(0, 0)
+	}
+}
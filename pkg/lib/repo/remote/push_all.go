@@ -0,0 +1,213 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/kitops-ml/kitops/pkg/output"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// PushAllOptions configures Repository.PushAll.
+type PushAllOptions struct {
+	// Concurrency bounds how many blobs PushAll uploads at once. Left at zero (or
+	// negative), it scales with the number of available CPUs, the same default
+	// unpackLayersConcurrently uses for the equivalent problem on the unpack side.
+	Concurrency int
+}
+
+// defaultPushConcurrency is PushAllOptions.Concurrency's fallback, capped the same way
+// unpackLayersConcurrently caps its own worker pool: these are network-bound uploads,
+// not CPU-bound work, but an unbounded GOMAXPROCS-sized pool on a large many-core box
+// would still open far more simultaneous connections to the registry than is polite.
+func defaultPushConcurrency() int {
+	return min(4, runtime.GOMAXPROCS(0))
+}
+
+// PushAll pushes every descriptor in descs to the repository, fanning out up to
+// opts.Concurrency blob uploads at once, and pushes the manifest -- identified by its
+// MediaType, the same way Push special-cases it -- only once every blob it references
+// has been confirmed present. opener is called once per descriptor that is uploaded
+// (not for a blob the registry already has), on whichever goroutine ends up uploading
+// it, to obtain that descriptor's content; PushAll closes
+// whatever opener returns once the upload finishes (or fails).
+//
+// A failed blob cancels every other in-flight upload rather than letting them run to
+// an outcome that no longer matters, and the manifest is never pushed in that case.
+// There is no cmd/push in this tree to plumb a --max-concurrent-uploads flag into, so
+// callers configure opts.Concurrency directly until that command exists.
+func (r *Repository) PushAll(ctx context.Context, descs []ocispec.Descriptor, opener func(ocispec.Descriptor) (io.ReadSeekCloser, error), opts PushAllOptions) error {
+	var manifest *ocispec.Descriptor
+	var blobs []ocispec.Descriptor
+	for _, desc := range descs {
+		if desc.MediaType == ocispec.MediaTypeImageManifest {
+			manifestCopy := desc
+			manifest = &manifestCopy
+			continue
+		}
+		blobs = append(blobs, desc)
+	}
+	if manifest == nil {
+		return fmt.Errorf("PushAll requires descs to include a manifest descriptor")
+	}
+
+	if err := r.pushBlobsConcurrently(ctx, blobs, opener, opts); err != nil {
+		return fmt.Errorf("failed to push one or more blobs: %w", err)
+	}
+
+	content, err := opener(*manifest)
+	if err != nil {
+		return fmt.Errorf("failed to open manifest content: %w", err)
+	}
+	defer content.Close()
+	return r.Push(ctx, *manifest, content)
+}
+
+// pushBlobsConcurrently runs a bounded worker pool over blobs, the same shape as
+// unpackLayersConcurrently: a single producer goroutine feeds a job channel, workers
+// drain it until it closes or ctx is cancelled, and the first error cancels ctx so the
+// remaining workers abort promptly.
+func (r *Repository) pushBlobsConcurrently(ctx context.Context, blobs []ocispec.Descriptor, opener func(ocispec.Descriptor) (io.ReadSeekCloser, error), opts PushAllOptions) error {
+	if len(blobs) == 0 {
+		return nil
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPushConcurrency()
+	}
+	if concurrency > len(blobs) {
+		concurrency = len(blobs)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	progress := newPushProgress(len(blobs))
+	if output.ProgressEnabled() {
+		output.SafeDebugf("Pushing %d blobs with %d concurrent uploads", len(blobs), concurrency)
+	}
+
+	blobCh := make(chan ocispec.Descriptor)
+	go func() {
+		defer close(blobCh)
+		for _, desc := range blobs {
+			select {
+			case blobCh <- desc:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for desc := range blobCh {
+				if err := r.pushBlob(ctx, desc, opener, progress); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// pushBlob uploads a single blob on behalf of pushBlobsConcurrently's worker pool. It
+// preflights with a HEAD request so a blob already present on the registry -- e.g. left
+// over from a previous, partially-failed PushAll call, or shared with another ModelKit
+// already pushed here -- is reported to progress and skipped rather than re-uploaded,
+// then uploads through initiateUploadSession/uploadBlob, the path Push takes for content
+// that isn't an io.ReaderAt, so chunking and warning surfacing behave as they do there.
+// Unlike Push, it never tries a cross-repository mount or the resumable
+// uploadBlobResume path.
+func (r *Repository) pushBlob(ctx context.Context, desc ocispec.Descriptor, opener func(ocispec.Descriptor) (io.ReadSeekCloser, error), progress *pushProgress) error {
+	ctx = auth.AppendRepositoryScope(ctx, r.Reference, auth.ActionPull, auth.ActionPush)
+
+	if r.blobExistsInRepo(ctx, r.Reference.Repository, desc) {
+		output.SafeDebugf("[%s] Blob already present in repository, skipping upload", desc.Digest.Encoded()[0:8])
+		progress.recordSkipped(desc)
+		return nil
+	}
+
+	content, err := opener(desc)
+	if err != nil {
+		return fmt.Errorf("failed to open content for blob %s: %w", desc.Digest, err)
+	}
+	defer content.Close()
+
+	sessionURL, postResp, err := r.initiateUploadSession(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := r.uploadBlob(ctx, sessionURL, postResp, desc, content); err != nil {
+		return fmt.Errorf("failed to push blob %s: %w", desc.Digest, err)
+	}
+	progress.recordPushed(desc)
+	return nil
+}
+
+// pushProgress aggregates per-blob outcomes across pushBlobsConcurrently's worker pool
+// into a single running total, so a multi-blob push reports one coherent summary
+// instead of each worker racing to print its own.
+type pushProgress struct {
+	mu      sync.Mutex
+	total   int
+	done    int
+	skipped int
+}
+
+func newPushProgress(total int) *pushProgress {
+	return &pushProgress{total: total}
+}
+
+func (p *pushProgress) recordPushed(desc ocispec.Descriptor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	if output.ProgressEnabled() {
+		output.SafeDebugf("[%s] Pushed blob (%d/%d)", desc.Digest.Encoded()[0:8], p.done+p.skipped, p.total)
+	}
+}
+
+func (p *pushProgress) recordSkipped(desc ocispec.Descriptor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.skipped++
+	if output.ProgressEnabled() {
+		output.SafeDebugf("[%s] Skipped blob already on registry (%d/%d)", desc.Digest.Encoded()[0:8], p.done+p.skipped, p.total)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2/registry"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func fakeManifestDescriptor() ocispec.Descriptor {
+	content := []byte("{}")
+	return ocispec.Descriptor{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+}
+
+// readSeekNopCloser adapts a *bytes.Reader, which already satisfies io.ReadSeeker,
+// into the io.ReadSeekCloser PushAll's opener must return.
+type readSeekNopCloser struct {
+	*bytes.Reader
+}
+
+func (readSeekNopCloser) Close() error { return nil }
+
+func newFakeOpener(contents map[string][]byte) func(ocispec.Descriptor) (io.ReadSeekCloser, error) {
+	return func(desc ocispec.Descriptor) (io.ReadSeekCloser, error) {
+		data, ok := contents[desc.Digest.String()]
+		if !ok {
+			return nil, fmt.Errorf("no content registered for digest %s", desc.Digest)
+		}
+		return readSeekNopCloser{bytes.NewReader(data)}, nil
+	}
+}
+
+func TestPushAllRequiresManifest(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	testRepo := Repository{
+		Reference: registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp: true,
+	}
+
+	blobDesc := ocispec.Descriptor{Digest: ocispec.DescriptorEmptyJSON.Digest, Size: 2}
+	err := testRepo.PushAll(t.Context(), []ocispec.Descriptor{blobDesc}, newFakeOpener(nil), PushAllOptions{})
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	assert.ErrorContains(t, err, "manifest")
+}
+
+// TestPushAllSkipsPresentBlobs checks that a blob the registry already has (per a HEAD
+// preflight) is never uploaded, but the manifest referencing it is still pushed once
+// every other blob succeeds.
+func TestPushAllSkipsPresentBlobs(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	presentDigest := ocispec.DescriptorEmptyJSON.Digest
+	presentDesc := ocispec.Descriptor{Digest: presentDigest, Size: 2}
+	manifestDesc := fakeManifestDescriptor()
+
+	var uploadAttempts int32
+	tc := &concurrentTestClient{
+		handler: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.Method == http.MethodHead:
+				if err := processRequest(req, http.MethodHead, "/v2/testrepo/blobs/"+presentDigest.String()); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusOK, "", nil)
+			case req.Method == http.MethodPost || req.Method == http.MethodPatch || req.Method == http.MethodPut:
+				atomic.AddInt32(&uploadAttempts, 1)
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "/v2/testrepo/manifests/testtag"})
+			default:
+				return nil, fmt.Errorf("unexpected method %s", req.Method)
+			}
+		},
+	}
+
+	testRepo := Repository{
+		Repository: &fakeManifestPusher{},
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	opener := newFakeOpener(map[string][]byte{
+		manifestDesc.Digest.String(): []byte("{}"),
+	})
+
+	err := testRepo.PushAll(t.Context(), []ocispec.Descriptor{presentDesc, manifestDesc}, opener, PushAllOptions{Concurrency: 2})
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&uploadAttempts), "a blob the registry already has should never be uploaded")
+	assert.True(t, testRepo.Repository.(*fakeManifestPusher).called, "manifest should be pushed once its blobs are confirmed")
+}
+
+// TestPushAllCancelsOnBlobFailure checks that one blob failing stops the rest of the
+// pool (rather than letting every blob run to completion) and that the manifest is
+// never pushed.
+func TestPushAllCancelsOnBlobFailure(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	failDigest := ocispec.DescriptorEmptyJSON.Digest
+	failDesc := ocispec.Descriptor{Digest: failDigest, Size: 2}
+	manifestDesc := fakeManifestDescriptor()
+
+	var mu sync.Mutex
+	headCalls := 0
+	tc := &concurrentTestClient{
+		handler: func(req *http.Request) (*http.Response, error) {
+			switch req.Method {
+			case http.MethodHead:
+				mu.Lock()
+				headCalls++
+				mu.Unlock()
+				// Blob isn't present; PushAll proceeds to a real upload attempt, which
+				// the registry then refuses outright.
+				return makeResponse(req, http.StatusNotFound, "", nil)
+			case http.MethodPost:
+				return makeResponse(req, http.StatusInternalServerError, "boom", nil)
+			default:
+				return nil, fmt.Errorf("unexpected method %s", req.Method)
+			}
+		},
+	}
+
+	testRepo := Repository{
+		Repository: &fakeManifestPusher{},
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	opener := newFakeOpener(map[string][]byte{
+		failDigest.String():          []byte("xx"),
+		manifestDesc.Digest.String(): []byte("{}"),
+	})
+
+	err := testRepo.PushAll(t.Context(), []ocispec.Descriptor{failDesc, manifestDesc}, opener, PushAllOptions{Concurrency: 2})
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	assert.Error(t, err)
+	assert.Equal(t, 1, headCalls, "the failed blob's preflight should run exactly once, not be retried by the pool")
+	assert.False(t, testRepo.Repository.(*fakeManifestPusher).called, "manifest should not be pushed when a blob fails")
+}
+
+// fakeManifestPusher stubs the embedded registry.Repository so PushAll's final
+// r.Push(ctx, manifest, ...) call -- which, for a manifest descriptor, forwards
+// straight to Repository.Repository.Push -- can be observed without a real registry.
+type fakeManifestPusher struct {
+	registry.Repository
+	called bool
+}
+
+func (f *fakeManifestPusher) Push(ctx context.Context, expected ocispec.Descriptor, content io.Reader) error {
+	f.called = true
+	return nil
+}
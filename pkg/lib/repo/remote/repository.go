@@ -17,10 +17,11 @@
 package remote
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
 	"net/url"
 	"path"
@@ -28,6 +29,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kitops-ml/kitops/pkg/cache/uploads"
+	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
 	"github.com/kitops-ml/kitops/pkg/output"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -43,11 +46,75 @@ type Repository struct {
 	PlainHttp       bool
 	Client          remote.Client
 	uploadChunkSize int64
+	// CacheDir is the kitops cache directory (see constants.DefaultConfigPath) under
+	// which resumable-upload state is persisted. Left empty, uploads simply aren't
+	// resumable: uploadBlobResume falls back to starting a fresh session every time.
+	CacheDir string
+	// DisableResume forces uploadBlobResume to always start a fresh upload session,
+	// ignoring (and clearing) any persisted state for the blob being pushed. It backs a
+	// `kit push --resume=false` style flag for users who'd rather not trust a stale
+	// session than wait for kitops to find out the hard way that the registry expired it.
+	DisableResume bool
+	// KnownRepos lists other repository paths under this Repository's registry that
+	// may already hold a layer being pushed here (e.g. other ModelKits in the local
+	// index that have previously been pushed to the same registry). Push tries to
+	// mount a blob from each of these before uploading it. Left empty, cross-repo
+	// mounting is simply skipped and every blob is uploaded in full.
+	KnownRepos []string
+	// ParallelUploadConcurrency bounds how many chunks of a single large, ReaderAt-
+	// backed blob uploadBlobResume uploads at once via uploadBlobParallel, rather than
+	// one sequential PATCH at a time. Left at zero (or one), parallel chunk upload is
+	// skipped and every blob goes through the existing sequential chunked path.
+	//
+	// Nothing in this tree sets this field yet, the same gap PushAllOptions.Concurrency
+	// documents: there is no `kit push` command here to plumb a --parallel-uploads
+	// style flag into. A caller that constructs its own Repository can still set it
+	// directly today; it just has no CLI surface in this tree yet.
+	ParallelUploadConcurrency int
+	// Encryption configures layer encryption and decryption. Left nil, EncryptLayer
+	// refuses to run and Fetch returns whatever bytes the registry sends, encrypted or
+	// not -- callers that need to read an encrypted layer's plaintext must configure
+	// DecryptionKeyPaths first.
+	//
+	// Nothing in this tree sets this field yet: there is no `kit push` command here to
+	// plumb an --encrypt/--recipient style flag into. PushLayer is the integration
+	// point a future push command should call through; until it exists, layer
+	// encryption is implemented and unit-tested but not exercised by any real push.
+	Encryption *EncryptionConfig
+	// cachedCrypter memoizes the LayerDecrypter built from Encryption; see crypter().
+	cachedCrypter *ocicryptLayerCrypter
 }
 
+// crypter lazily builds r.Encryption into a LayerDecrypter, caching the result so a
+// Fetch-heavy pull (e.g. unpacking every layer of a ModelKit) doesn't re-parse key files
+// on every call.
+func (r *Repository) crypter() (*ocicryptLayerCrypter, error) {
+	if r.Encryption == nil {
+		return nil, nil
+	}
+	if r.cachedCrypter == nil {
+		c, err := NewLayerCrypter(*r.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		r.cachedCrypter = c
+	}
+	return r.cachedCrypter, nil
+}
+
+// errUploadSessionExpired indicates the registry no longer recognizes a persisted
+// upload location (e.g. it returned 404 BlobUploadUnknown on the status GET), meaning the
+// session must be restarted from scratch rather than resumed.
+var errUploadSessionExpired = errors.New("upload session no longer exists on remote registry")
+
 // Make this available for subbing out in tests
 var retryPolicy = retry.DefaultPolicy
 
+// maxAuthRefreshes bounds how many times uploadBlobChunked will refresh credentials and
+// retry a chunk in response to repeated 401s, so a registry that keeps rejecting
+// refreshed tokens fails the upload instead of looping forever.
+const maxAuthRefreshes = 3
+
 func (r *Repository) Untag(ctx context.Context, reference string) error {
 	if err := r.Reference.ValidateReferenceAsDigest(); err == nil {
 		return fmt.Errorf("cannot untag using digest")
@@ -63,6 +130,7 @@ func (r *Repository) Untag(ctx context.Context, reference string) error {
 		return fmt.Errorf("failed to untag: %w", err)
 	}
 	defer resp.Body.Close()
+	surfaceWarnings(resp)
 	switch resp.StatusCode {
 	case http.StatusBadRequest, http.StatusMethodNotAllowed:
 		return fmt.Errorf("remote registry does not support untagging")
@@ -82,11 +150,49 @@ func (r *Repository) Push(ctx context.Context, expected ocispec.Descriptor, cont
 		return r.Repository.Push(ctx, expected, content)
 	}
 
+	// Before uploading anything, see if some other repository on this registry already
+	// has this exact blob; if so the registry can copy it server-side and we can skip
+	// the upload entirely. A candidate that turns the registry down for a mount (202)
+	// still leaves us an upload session we can reuse rather than discard.
+	var sessionURL *url.URL
+	var postResp *http.Response
+	if len(r.KnownRepos) > 0 {
+		mounted, location, fallbackSession, fallbackPostResp, err := r.mountBlob(ctx, expected, r.KnownRepos)
+		if err != nil {
+			return err
+		}
+		if mounted {
+			output.SafeDebugf("[%s] Blob mounted from existing repository, available at url %s", expected.Digest.Encoded()[0:8], location)
+			return nil
+		}
+		sessionURL, postResp = fallbackSession, fallbackPostResp
+	}
+
+	// If the content supports random access (e.g. it's backed by a file on disk), route
+	// the upload through the resumable path so a push killed mid-upload can pick up
+	// where it left off on the next run instead of starting over from byte zero. This
+	// only applies when we don't already have a session open from a mount attempt
+	// above; reusing that one is simpler than trying to fold it into uploadBlobResume's
+	// own session bookkeeping.
+	if sessionURL == nil {
+		if ra, ok := content.(io.ReaderAt); ok {
+			blobUrl, err := r.uploadBlobResume(ctx, expected, ra)
+			if err != nil {
+				return err
+			}
+			output.SafeDebugf("[%s] Blob uploaded, available at url %s", expected.Digest.Encoded()[0:8], blobUrl)
+			return nil
+		}
+	}
+
 	// Otherwise, push a blob according to the OCI spec
 	ctx = auth.AppendRepositoryScope(ctx, r.Reference, auth.ActionPull, auth.ActionPush)
-	sessionURL, postResp, err := r.initiateUploadSession(ctx)
-	if err != nil {
-		return err
+	if sessionURL == nil {
+		var err error
+		sessionURL, postResp, err = r.initiateUploadSession(ctx)
+		if err != nil {
+			return err
+		}
 	}
 
 	blobUrl, err := r.uploadBlob(ctx, sessionURL, postResp, expected, content)
@@ -98,6 +204,242 @@ func (r *Repository) Push(ctx context.Context, expected ocispec.Descriptor, cont
 	return nil
 }
 
+// EncryptLayer encrypts plain for r.Encryption's configured recipients and returns the
+// ciphertext along with the descriptor that must be used in its place everywhere else --
+// in the layer's manifest entry and as the expected descriptor passed to Push. Unlike
+// Push, EncryptLayer can't be hidden behind the existing content.Pusher-shaped Push
+// method: encryption changes the digest and size the caller builds the manifest around,
+// so the manifest has to be assembled from the descriptor EncryptLayer returns, not the
+// plaintext one. Callers that don't need encryption never call this; Push and Fetch
+// behave exactly as before for a Repository with Encryption left nil.
+func (r *Repository) EncryptLayer(plain io.Reader, desc ocispec.Descriptor) (io.ReadSeeker, ocispec.Descriptor, error) {
+	c, err := r.crypter()
+	if err != nil {
+		return nil, ocispec.Descriptor{}, err
+	}
+	if c == nil {
+		return nil, ocispec.Descriptor{}, fmt.Errorf("cannot encrypt layer %s: repository has no EncryptionConfig", desc.Digest)
+	}
+	return c.Encrypt(plain, desc)
+}
+
+// PushLayer pushes plain to r, transparently encrypting it first if r.Encryption is
+// configured. It returns the descriptor that was actually pushed -- desc unchanged when
+// Encryption is nil, or the ciphertext descriptor EncryptLayer produced otherwise --
+// which the caller must use in place of desc everywhere else, in particular when
+// assembling the manifest that will reference this layer.
+//
+// Nothing in this tree calls PushLayer yet. It exists so a future `kit push` command
+// (this tree has none) has a single, correct place to route layer uploads through
+// when --encrypt/--recipient is set, rather than each caller having to remember to
+// call EncryptLayer itself before Push. Until that command exists, this is tested,
+// reviewed library code with no production caller -- not an active code path, and
+// the encryption subsystem behind it (both push and pull sides) never activates in
+// this tree no matter how Repository is configured.
+func (r *Repository) PushLayer(ctx context.Context, plain io.Reader, desc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	if r.Encryption == nil {
+		if err := r.Push(ctx, desc, plain); err != nil {
+			return ocispec.Descriptor{}, err
+		}
+		return desc, nil
+	}
+	cipher, cipherDesc, err := r.EncryptLayer(plain, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	if err := r.Push(ctx, cipherDesc, cipher); err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	return cipherDesc, nil
+}
+
+// Fetch fetches the content identified by target, transparently decrypting it first if
+// its media type carries mediatype.EncryptedSuffix and r.Encryption is configured to do
+// so. Decryption is fully streaming: it wraps the registry's response body rather than
+// buffering it, unlike EncryptLayer.
+//
+// With r.Encryption left nil, Fetch returns whatever bytes the registry sends,
+// encrypted or not -- per Encryption's own doc comment. That's deliberate: callers like
+// unpack/core.go's decryptLayerIfNeeded decrypt with a user-supplied --decryption-key
+// after Fetch returns, and a hard error here for every encrypted layer would make that
+// path unreachable for any layer that actually came from a registry.
+func (r *Repository) Fetch(ctx context.Context, target ocispec.Descriptor) (io.ReadCloser, error) {
+	rc, err := r.Repository.Fetch(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	if !mediatype.IsEncryptedMediaTypeString(target.MediaType) {
+		return rc, nil
+	}
+	c, err := r.crypter()
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	if c == nil {
+		return rc, nil
+	}
+	plain, err := c.Decrypt(rc, target)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return plain, nil
+}
+
+// errResumeOffsetInvalid is returned by FetchFrom when the registry rejects the
+// requested offset outright (416), rather than just ignoring the Range header -- the
+// caller should treat the resume as unrecoverable and restart the blob from zero
+// instead of retrying the same offset.
+var errResumeOffsetInvalid = errors.New("registry rejected resume offset as out of range")
+
+// FetchFrom fetches target starting at byte offset, via an HTTP Range request against
+// the blob's canonical URL, so a caller that already has the first offset bytes on
+// disk (e.g. a resumable `kit dev` extraction) doesn't have to re-download them. An
+// offset of 0 or less is equivalent to Fetch.
+//
+// Distribution-spec Range support is best-effort: a registry is free to ignore the
+// header and return the whole blob (200) instead of honoring it (206), so FetchFrom
+// detects that case and skips ahead in the response itself, and a registry that
+// rejects the offset outright (416) returns errResumeOffsetInvalid so the caller knows
+// to restart from zero rather than retry the same request.
+func (r *Repository) FetchFrom(ctx context.Context, target ocispec.Descriptor, offset int64) (io.ReadCloser, error) {
+	if offset <= 0 {
+		return r.Fetch(ctx, target)
+	}
+	if offset >= target.Size {
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+	if mediatype.IsEncryptedMediaTypeString(target.MediaType) {
+		// The cipher stream's state at offset depends on every plaintext byte before
+		// it, which a fresh Range request can't reconstruct; resuming from partway
+		// through an encrypted layer would silently produce corrupt plaintext.
+		return nil, fmt.Errorf("cannot resume fetching encrypted layer %s from a nonzero offset", target.Digest)
+	}
+
+	blobURL := buildRepositoryBlobURL(r.PlainHttp, r.Reference, target.Digest.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resume blob fetch: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return resp.Body, nil
+	case http.StatusOK:
+		// Registry ignored the Range header and sent the whole blob from the start;
+		// skip ahead so the caller still resumes from the right place.
+		if _, err := io.CopyN(io.Discard, resp.Body, offset); err != nil {
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to skip to resume offset: %w", err)
+		}
+		return resp.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		resp.Body.Close()
+		return nil, errResumeOffsetInvalid
+	default:
+		defer resp.Body.Close()
+		return nil, handleRemoteError(resp)
+	}
+}
+
+// mountBlob attempts to mount expected from each repository in fromRepos, in order,
+// using the distribution spec's cross-repository blob mount
+// (POST .../blobs/uploads/?mount=<digest>&from=<repo>). Before spending a mount
+// attempt (which, per the spec, always opens an upload session even when the mount
+// itself fails) it first confirms with a HEAD request that the candidate actually has
+// the blob, so repos that obviously don't have it never cost us a throwaway session.
+//
+// A 201 response means the registry copied the blob server-side without the client
+// uploading anything; mounted is true and location is where the blob now lives. A 202
+// means the registry declined the mount (e.g. it has mounting disabled) but opened a
+// normal upload session anyway, per spec; that session is returned as fallbackSession
+// and fallbackPostResp so the caller can upload through it instead of opening a second
+// one. Any other response means that candidate didn't work, so mountBlob moves on to
+// the next one; if none work, mounted is false and fallbackSession is nil, meaning the
+// caller should start a fresh upload session itself.
+func (r *Repository) mountBlob(ctx context.Context, expected ocispec.Descriptor, fromRepos []string) (mounted bool, location string, fallbackSession *url.URL, fallbackPostResp *http.Response, err error) {
+	ctx = auth.AppendRepositoryScope(ctx, r.Reference, auth.ActionPull, auth.ActionPush)
+	for _, fromRepo := range fromRepos {
+		if fromRepo == r.Reference.Repository {
+			continue
+		}
+		fromRef := r.Reference
+		fromRef.Repository = fromRepo
+		sourceCtx := auth.AppendRepositoryScope(ctx, fromRef, auth.ActionPull)
+
+		if !r.blobExistsInRepo(sourceCtx, fromRepo, expected) {
+			output.SafeDebugf("[%s] %s does not have blob, skipping mount candidate", expected.Digest.Encoded()[0:8], fromRepo)
+			continue
+		}
+
+		mountUrl := buildRepositoryBlobUploadURL(r.PlainHttp, r.Reference)
+		req, err := http.NewRequestWithContext(sourceCtx, http.MethodPost, mountUrl, nil)
+		if err != nil {
+			return false, "", nil, nil, err
+		}
+		q := req.URL.Query()
+		q.Set("mount", expected.Digest.String())
+		q.Set("from", fromRepo)
+		req.URL.RawQuery = q.Encode()
+
+		output.SafeDebugf("[%s] Attempting to mount blob from repository %s", expected.Digest.Encoded()[0:8], fromRepo)
+		resp, err := r.client().Do(req)
+		if err != nil {
+			return false, "", nil, nil, fmt.Errorf("failed to attempt blob mount: %w", err)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusCreated:
+			resp.Body.Close()
+			mountedLocation, err := resp.Location()
+			if err != nil {
+				output.Errorf("Warning: remote registry did not return blob location after mount (layer digest %s)", expected.Digest.Encoded()[0:8])
+				return true, "", nil, nil, nil
+			}
+			return true, mountedLocation.String(), nil, nil, nil
+		case http.StatusAccepted:
+			output.SafeDebugf("[%s] Registry declined mount from %s, reusing the upload session it opened instead", expected.Digest.Encoded()[0:8], fromRepo)
+			sessionLocation, err := resp.Location()
+			resp.Body.Close()
+			if err != nil {
+				output.SafeDebugf("[%s] Registry did not return an upload location with the mount fallback, trying next candidate", expected.Digest.Encoded()[0:8])
+				continue
+			}
+			return false, "", sessionLocation, resp, nil
+		default:
+			resp.Body.Close()
+			output.SafeDebugf("[%s] Mount from %s not accepted (status %s), trying next candidate", expected.Digest.Encoded()[0:8], fromRepo, resp.Status)
+		}
+	}
+	return false, "", nil, nil, nil
+}
+
+// blobExistsInRepo issues a HEAD request to confirm repo, a candidate source for
+// mountBlob, actually has expected before spending a mount attempt on it.
+func (r *Repository) blobExistsInRepo(ctx context.Context, repo string, expected ocispec.Descriptor) bool {
+	sourceRef := r.Reference
+	sourceRef.Repository = repo
+	blobUrl := buildRepositoryBlobURL(r.PlainHttp, sourceRef, expected.Digest.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, blobUrl, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
 func (r *Repository) initiateUploadSession(ctx context.Context) (*url.URL, *http.Response, error) {
 	uploadUrl := buildRepositoryBlobUploadURL(r.PlainHttp, r.Reference)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadUrl, nil)
@@ -105,15 +447,12 @@ func (r *Repository) initiateUploadSession(ctx context.Context) (*url.URL, *http
 		return nil, nil, err
 	}
 
-	// TODO: Handle warnings from remote
-	// References:
-	//   - https://github.com/opencontainers/distribution-spec/blob/v1.1.0-rc4/spec.md#warnings
-	//   - https://www.rfc-editor.org/rfc/rfc7234#section-5.5
 	resp, err := r.client().Do(req)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initiate upload: %w", err)
 	}
 	defer resp.Body.Close()
+	surfaceWarnings(resp)
 	if resp.StatusCode != http.StatusAccepted {
 		return nil, nil, handleRemoteError(resp)
 	}
@@ -144,12 +483,28 @@ func (r *Repository) uploadBlob(ctx context.Context, location *url.URL, postResp
 	case uploadMonolithicPut:
 		return r.uploadBlobMonolithic(ctx, location, authHeader, expected, content)
 	case uploadChunkedPatch:
-		return r.uploadBlobChunked(ctx, location, authHeader, expected, content)
+		return r.uploadBlobChunked(ctx, location, NewChallengeManager(authHeader), expected, content, parseChunkMinLength(postResp))
 	default:
 		return "", fmt.Errorf("unknown registry %s, cannot upload", location.Hostname())
 	}
 }
 
+// parseChunkMinLength reads the OCI-Chunk-Min-Length header from a registry's upload
+// session response, if present. A registry sets this to tell clients the smallest
+// chunk size it's willing to accept; an invalid or missing header means the registry
+// didn't advertise a preference, so callers should fall back to their own default.
+func parseChunkMinLength(postResp *http.Response) int64 {
+	raw := postResp.Header.Get("OCI-Chunk-Min-Length")
+	if raw == "" {
+		return 0
+	}
+	minLength, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || minLength <= 0 {
+		return 0
+	}
+	return minLength
+}
+
 // uploadBlobMonolithic performs a monolithic blob upload as per the distribution spec. The content of the blob is uploaded
 // in one PUT request at the provided location.
 func (r *Repository) uploadBlobMonolithic(ctx context.Context, location *url.URL, authHeader string, expected ocispec.Descriptor, content io.Reader) (string, error) {
@@ -178,15 +533,12 @@ func (r *Repository) uploadBlobMonolithic(ctx context.Context, location *url.URL
 	}
 
 	output.SafeDebugf("[%s] Uploading blob as one chunk", expected.Digest.Encoded()[0:8])
-	// TODO: Handle warnings from remote
-	// References:
-	//   - https://github.com/opencontainers/distribution-spec/blob/v1.1.0-rc4/spec.md#warnings
-	//   - https://www.rfc-editor.org/rfc/rfc7234#section-5.5
 	resp, err := r.client().Do(req)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload blob: %w", err)
 	}
 	defer resp.Body.Close()
+	surfaceWarnings(resp)
 
 	if resp.StatusCode != http.StatusCreated {
 		return "", handleRemoteError(resp)
@@ -204,15 +556,32 @@ func (r *Repository) uploadBlobMonolithic(ctx context.Context, location *url.URL
 // in size and uploaded sequentially through PATCH requests. Once entire blob is uploaded, a PUT request marks the upload as complete.
 // Note that the distribution spec 1) requires blobs to uploaded in-order, and 2) does not have a way of specifying maximum blob
 // size.
-func (r *Repository) uploadBlobChunked(ctx context.Context, location *url.URL, authHeader string, expected ocispec.Descriptor, content io.Reader) (string, error) {
-	// TODO: Handle 'OCI-Chunk-Min-Length' header in post response
-	numChunks := int(math.Ceil(float64(expected.Size) / float64(r.uploadChunkSize)))
+//
+// The chunk size isn't fixed for the whole upload: minChunkSize (parsed from the
+// registry's OCI-Chunk-Min-Length response header, or 0 if it didn't send one) raises
+// the starting size, a short Range in a PATCH response shrinks how far we advance (the
+// registry is allowed to accept less than we sent), a 413 response halves the chunk
+// size and retries the same range, and a 416 response resyncs to the offset the
+// registry reports in its Range header. The last three require content to support
+// io.Seeker so a rejected or partially-accepted chunk can be re-read from the registry's
+// confirmed offset instead of the one we guessed.
+//
+// authn supplies the Authorization header and, on a 401 partway through the upload,
+// refreshes it against the challenge the registry sends back, so a bearer token
+// expiring mid-upload doesn't fail a multi-gigabyte push outright.
+func (r *Repository) uploadBlobChunked(ctx context.Context, location *url.URL, authn Authenticator, expected ocispec.Descriptor, content io.Reader, minChunkSize int64) (string, error) {
+	chunkSize := r.uploadChunkSize
+	if minChunkSize > chunkSize {
+		output.SafeDebugf("[%s] Registry requested minimum chunk size %d, raising from %d", expected.Digest.Encoded()[0:8], minChunkSize, chunkSize)
+		chunkSize = minChunkSize
+	}
 
 	rangeStart := int64(0)
-	rangeEnd := min(r.uploadChunkSize-1, expected.Size-1)
 	nextLocation := location
-	for i := range numChunks {
-		output.SafeDebugf("[%s] Uploading chunk %d/%d, range %d-%d", expected.Digest.Encoded()[0:8], i+1, numChunks, rangeStart, rangeEnd)
+	authRefreshes := 0
+	for rangeStart < expected.Size {
+		rangeEnd := min(rangeStart+chunkSize-1, expected.Size-1)
+		output.SafeDebugf("[%s] Uploading chunk range %d-%d", expected.Digest.Encoded()[0:8], rangeStart, rangeEnd)
 
 		// Set up request without body to allow rewinding/retries
 		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, nextLocation.String(), nil)
@@ -222,16 +591,55 @@ func (r *Repository) uploadBlobChunked(ctx context.Context, location *url.URL, a
 		req.ContentLength = rangeEnd - rangeStart + 1
 		req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", rangeStart, rangeEnd))
 		req.Header.Set("Content-Type", "application/octet-stream")
-		if authHeader != "" {
-			req.Header.Set("Authorization", authHeader)
+		if h := authn.Header(); h != "" {
+			req.Header.Set("Authorization", h)
 		}
 
 		// Submit the chunk as a PATCH
-		// TODO: Handle 416 response code (range not satisfiable)
 		resp, err := r.uploadBlobChunkWithRetry(ctx, req, content, expected, rangeStart, rangeEnd)
 		if err != nil {
 			return "", fmt.Errorf("failed to upload blob chunk: %w", err)
 		}
+		surfaceWarnings(resp)
+
+		if resp.StatusCode == http.StatusUnauthorized {
+			resp.Body.Close()
+			if authRefreshes >= maxAuthRefreshes {
+				return "", fmt.Errorf("registry rejected refreshed credentials for blob %s", expected.Digest)
+			}
+			authRefreshes++
+			output.SafeDebugf("[%s] Received 401, refreshing credentials and retrying chunk", expected.Digest.Encoded()[0:8])
+			if err := authn.Refresh(ctx, r.client(), resp.Header.Get("WWW-Authenticate")); err != nil {
+				return "", fmt.Errorf("failed to refresh credentials after 401: %w", err)
+			}
+			seekableContent, ok := content.(io.Seeker)
+			if !ok {
+				return "", fmt.Errorf("received 401 mid-upload and content is not seekable; cannot retry with refreshed credentials")
+			}
+			if _, err := seekableContent.Seek(rangeStart, io.SeekStart); err != nil {
+				return "", fmt.Errorf("failed to rewind content after refreshing credentials: %w", err)
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusRequestEntityTooLarge {
+			resp.Body.Close()
+			newChunkSize, err := shrinkChunkSize(content, chunkSize, rangeStart, expected)
+			if err != nil {
+				return "", err
+			}
+			chunkSize = newChunkSize
+			continue
+		}
+		if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			newRangeStart, err := recoverFromRangeNotSatisfiable(content, resp, rangeStart, expected)
+			resp.Body.Close()
+			if err != nil {
+				return "", err
+			}
+			rangeStart = newRangeStart
+			continue
+		}
 		if resp.StatusCode != http.StatusAccepted {
 			defer resp.Body.Close()
 			return "", handleRemoteError(resp)
@@ -246,26 +654,24 @@ func (r *Repository) uploadBlobChunked(ctx context.Context, location *url.URL, a
 		}
 		nextLocation = respLocation
 
-		// Verify Range header in response matches what we expect
-		respRange := resp.Header.Get("Range")
-		if respRange == "" {
-			return "", fmt.Errorf("missing Range header in response")
-		}
-		startEnd := strings.Split(respRange, "-")
-		if len(startEnd) != 2 || startEnd[0] != "0" {
-			return "", fmt.Errorf("server returned invalid Range header: %s", respRange)
-		}
-		curEnd, err := strconv.ParseInt(startEnd[1], 10, 0)
+		acceptedEnd, err := parseAcceptedRange(resp, rangeEnd)
 		if err != nil {
-			return "", fmt.Errorf("server returned invalid Range header: %s", respRange)
+			return "", err
 		}
-		if curEnd != rangeEnd {
-			return "", fmt.Errorf("mismatch in range header: expected 0-%d, actual 0-%d", rangeEnd, curEnd)
+		if acceptedEnd < rangeEnd {
+			// Registry accepted fewer bytes than we sent; rewind to resume exactly where
+			// it actually stopped rather than assuming full acceptance.
+			seekableContent, ok := content.(io.Seeker)
+			if !ok {
+				return "", fmt.Errorf("registry accepted only %d of %d requested bytes and content is not seekable; cannot resume", acceptedEnd-rangeStart+1, rangeEnd-rangeStart+1)
+			}
+			if _, err := seekableContent.Seek(acceptedEnd+1, io.SeekStart); err != nil {
+				return "", fmt.Errorf("failed to rewind content to resume upload: %w", err)
+			}
 		}
 
 		// Prepare next range
-		rangeStart = rangeEnd + 1
-		rangeEnd = min(expected.Size-1, rangeEnd+r.uploadChunkSize)
+		rangeStart = acceptedEnd + 1
 	}
 
 	// Final PUT request to mark upload as completed for server. Note that the final chunk _could_ be included in this
@@ -278,9 +684,9 @@ func (r *Repository) uploadBlobChunked(ctx context.Context, location *url.URL, a
 	q := req.URL.Query()
 	q.Set("digest", expected.Digest.String())
 	req.URL.RawQuery = q.Encode()
-	// Reuse credentials from POST request that initiated upload
-	if authHeader != "" {
-		req.Header.Set("Authorization", authHeader)
+	// Reuse (possibly refreshed) credentials from the chunked upload
+	if h := authn.Header(); h != "" {
+		req.Header.Set("Authorization", h)
 	}
 
 	output.SafeDebugf("[%s] Finalizing upload", expected.Digest.Encoded()[0:8])
@@ -289,6 +695,7 @@ func (r *Repository) uploadBlobChunked(ctx context.Context, location *url.URL, a
 		return "", fmt.Errorf("failed to finalize blob upload: %w", err)
 	}
 	defer resp.Body.Close()
+	surfaceWarnings(resp)
 
 	if resp.StatusCode != http.StatusCreated {
 		return "", handleRemoteError(resp)
@@ -302,6 +709,282 @@ func (r *Repository) uploadBlobChunked(ctx context.Context, location *url.URL, a
 	return blobLocation.String(), nil
 }
 
+// parseAcceptedRange reads the Range header off a successful chunk PATCH response and
+// returns the last byte offset the registry actually accepted, which the spec allows to
+// be smaller than sentEnd (the offset we requested).
+func parseAcceptedRange(resp *http.Response, sentEnd int64) (int64, error) {
+	respRange := resp.Header.Get("Range")
+	if respRange == "" {
+		return 0, fmt.Errorf("missing Range header in response")
+	}
+	startEnd := strings.Split(respRange, "-")
+	if len(startEnd) != 2 || startEnd[0] != "0" {
+		return 0, fmt.Errorf("server returned invalid Range header: %s", respRange)
+	}
+	acceptedEnd, err := strconv.ParseInt(startEnd[1], 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("server returned invalid Range header: %s", respRange)
+	}
+	if acceptedEnd > sentEnd {
+		return 0, fmt.Errorf("server accepted more bytes (0-%d) than were sent (0-%d)", acceptedEnd, sentEnd)
+	}
+	return acceptedEnd, nil
+}
+
+// shrinkChunkSize responds to a 413 (Payload Too Large) from the registry by halving
+// chunkSize and rewinding content to rangeStart, so the next attempt resends the same
+// range as a smaller request instead of skipping ahead.
+func shrinkChunkSize(content io.Reader, chunkSize, rangeStart int64, expected ocispec.Descriptor) (int64, error) {
+	if chunkSize <= 1 {
+		return 0, fmt.Errorf("registry rejected chunk size even at the minimum of 1 byte for blob %s", expected.Digest)
+	}
+	seekableContent, ok := content.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("registry rejected chunk size and content is not seekable; cannot retry with a smaller chunk")
+	}
+	if _, err := seekableContent.Seek(rangeStart, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind content after chunk size reduction: %w", err)
+	}
+	newChunkSize := chunkSize / 2
+	output.SafeDebugf("[%s] Registry rejected chunk size %d, retrying with %d", expected.Digest.Encoded()[0:8], chunkSize, newChunkSize)
+	return newChunkSize, nil
+}
+
+// recoverFromRangeNotSatisfiable responds to a 416 (Range Not Satisfiable) from the
+// registry -- typically meaning the chunk we sent doesn't start where the registry
+// thinks the upload left off, e.g. after a prior chunk was only partially durable on
+// the server's end. Per the distribution spec, the 416 response carries a Range header
+// with the offset the registry actually has; this reads that, seeks content to just
+// past it, and returns the corrected rangeStart for the caller's loop to resume from. A
+// 416 with no Range header can't be resynced this way, so it's treated like any other
+// unrecoverable upload error.
+func recoverFromRangeNotSatisfiable(content io.Reader, resp *http.Response, rangeStart int64, expected ocispec.Descriptor) (int64, error) {
+	respRange := resp.Header.Get("Range")
+	if respRange == "" {
+		return 0, fmt.Errorf("registry returned 416 Range Not Satisfiable with no Range header for blob %s", expected.Digest)
+	}
+	startEnd := strings.Split(respRange, "-")
+	if len(startEnd) != 2 || startEnd[0] != "0" {
+		return 0, fmt.Errorf("server returned invalid Range header: %s", respRange)
+	}
+	committedEnd, err := strconv.ParseInt(startEnd[1], 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("server returned invalid Range header: %s", respRange)
+	}
+
+	seekableContent, ok := content.(io.Seeker)
+	if !ok {
+		return 0, fmt.Errorf("registry returned 416 Range Not Satisfiable and content is not seekable; cannot resync to offset %d", committedEnd+1)
+	}
+	if _, err := seekableContent.Seek(committedEnd+1, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to rewind content after 416: %w", err)
+	}
+	output.SafeDebugf("[%s] Registry returned 416 for range starting at %d, resyncing to its committed offset %d", expected.Digest.Encoded()[0:8], rangeStart, committedEnd+1)
+	return committedEnd + 1, nil
+}
+
+// uploadKey returns the uploads cache key identifying expected's upload session
+// against this repository.
+func (r *Repository) uploadKey(expected ocispec.Descriptor) uploads.Key {
+	return uploads.Key{Registry: r.Reference.Registry, Repository: r.Reference.Repository, Digest: expected.Digest.String()}
+}
+
+// uploadBlobResume is the entry point for a chunked blob upload backed by
+// random-access content (e.g. a file on disk). If a prior, interrupted upload of the
+// same digest to this repository left state behind, it confirms with the registry how
+// much of the blob it already has (per the OCI distribution spec's Range header
+// semantics) and continues from there; otherwise it starts a fresh upload session, the
+// same as uploadBlob would. Setting r.DisableResume clears that state instead of using
+// it, for a `kit push --resume=false` style override that always starts over; the new
+// session's progress is still saved after each chunk, as usual.
+func (r *Repository) uploadBlobResume(ctx context.Context, expected ocispec.Descriptor, content io.ReaderAt) (string, error) {
+	ctx = auth.AppendRepositoryScope(ctx, r.Reference, auth.ActionPull, auth.ActionPush)
+	key := r.uploadKey(expected)
+
+	if r.DisableResume {
+		if clearErr := uploads.Clear(r.CacheDir, key); clearErr != nil {
+			output.SafeDebugf("[%s] Failed to clear upload state before a non-resumed push: %s", expected.Digest.Encoded()[0:8], clearErr)
+		}
+	} else if state, ok, err := uploads.Load(r.CacheDir, key); err != nil {
+		output.SafeDebugf("[%s] Failed to load resumable upload state, starting over: %s", expected.Digest.Encoded()[0:8], err)
+	} else if ok {
+		location, offset, err := r.confirmUploadOffset(ctx, state.NextLocation)
+		switch {
+		case err == nil:
+			output.SafeDebugf("[%s] Resuming upload at offset %d", expected.Digest.Encoded()[0:8], offset)
+			return r.uploadBlobChunkedFrom(ctx, key, location, "", expected, content, offset, state.ChunkSize)
+		case errors.Is(err, errUploadSessionExpired):
+			output.SafeDebugf("[%s] Resumable upload session expired, starting over", expected.Digest.Encoded()[0:8])
+			if clearErr := uploads.Clear(r.CacheDir, key); clearErr != nil {
+				output.SafeDebugf("[%s] Failed to clear stale upload state: %s", expected.Digest.Encoded()[0:8], clearErr)
+			}
+		default:
+			return "", err
+		}
+	}
+
+	sessionURL, postResp, err := r.initiateUploadSession(ctx)
+	if err != nil {
+		return "", err
+	}
+	authHeader := postResp.Request.Header.Get("Authorization")
+
+	if getUploadFormat(sessionURL.Hostname(), expected.Size, r.uploadChunkSize) == uploadMonolithicPut {
+		return r.uploadBlobMonolithic(ctx, sessionURL, authHeader, expected, io.NewSectionReader(content, 0, expected.Size))
+	}
+	// A fresh session (as opposed to one we're resuming mid-upload) is the only case
+	// uploadBlobParallel supports: it always starts its PATCHes from byte zero. It's
+	// opt-in via ParallelUploadConcurrency, and downgrades itself to
+	// uploadBlobChunkedFrom if the registry rejects a non-contiguous chunk.
+	if r.ParallelUploadConcurrency > 1 {
+		return r.uploadBlobParallel(ctx, sessionURL, NewChallengeManager(authHeader), expected, content, r.ParallelUploadConcurrency)
+	}
+	return r.uploadBlobChunkedFrom(ctx, key, sessionURL, authHeader, expected, content, 0, r.uploadChunkSize)
+}
+
+// confirmUploadOffset issues a GET request against a persisted upload location -- the
+// distribution spec's "obtain status" request -- to confirm how many bytes the registry
+// has actually received, per the "Range: 0-<offset>" response header on the upload
+// session. It returns errUploadSessionExpired if the registry no longer recognizes the
+// session (404, or 410 if the registry has since garbage-collected it).
+func (r *Repository) confirmUploadOffset(ctx context.Context, location string) (*url.URL, int64, error) {
+	parsedLocation, err := url.Parse(location)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid persisted upload location %q: %w", location, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedLocation.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to confirm upload progress: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, 0, errUploadSessionExpired
+	}
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return nil, 0, handleRemoteError(resp)
+	}
+
+	respRange := resp.Header.Get("Range")
+	if respRange == "" {
+		// No bytes confirmed yet; resume from the start of this session.
+		return parsedLocation, 0, nil
+	}
+	startEnd := strings.Split(respRange, "-")
+	if len(startEnd) != 2 || startEnd[0] != "0" {
+		return nil, 0, fmt.Errorf("server returned invalid Range header: %s", respRange)
+	}
+	lastByte, err := strconv.ParseInt(startEnd[1], 10, 0)
+	if err != nil {
+		return nil, 0, fmt.Errorf("server returned invalid Range header: %s", respRange)
+	}
+	return parsedLocation, lastByte + 1, nil
+}
+
+// uploadBlobChunkedFrom performs a chunked blob upload, as uploadBlobChunked does, but
+// starting at startOffset rather than byte zero, reading chunks from content on demand
+// via io.ReaderAt rather than consuming a single forward-only io.Reader. After every
+// successful PATCH it persists progress under key so the upload can be resumed by a
+// later call to uploadBlobResume; state is cleared once the final PUT succeeds; it is
+// also cleared (not resumed) on signs the registry has discarded the session,
+// mirroring uploadBlobResume's own handling of an expired session.
+func (r *Repository) uploadBlobChunkedFrom(ctx context.Context, key uploads.Key, location *url.URL, authHeader string, expected ocispec.Descriptor, content io.ReaderAt, startOffset, chunkSize int64) (string, error) {
+	if chunkSize <= 0 {
+		chunkSize = r.uploadChunkSize
+	}
+
+	nextLocation := location
+	rangeStart := startOffset
+	for rangeStart < expected.Size {
+		rangeEnd := min(rangeStart+chunkSize-1, expected.Size-1)
+		chunk := io.NewSectionReader(content, rangeStart, rangeEnd-rangeStart+1)
+
+		output.SafeDebugf("[%s] Uploading chunk range %d-%d", expected.Digest.Encoded()[0:8], rangeStart, rangeEnd)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPatch, nextLocation.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		req.ContentLength = rangeEnd - rangeStart + 1
+		req.Header.Set("Content-Range", fmt.Sprintf("%d-%d", rangeStart, rangeEnd))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		if authHeader != "" {
+			req.Header.Set("Authorization", authHeader)
+		}
+
+		resp, err := r.uploadBlobChunkWithRetry(ctx, req, chunk, expected, 0, rangeEnd-rangeStart)
+		if err != nil {
+			return "", fmt.Errorf("failed to upload blob chunk: %w", err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			resp.Body.Close()
+			if clearErr := uploads.Clear(r.CacheDir, key); clearErr != nil {
+				output.SafeDebugf("[%s] Failed to clear upload state after 404: %s", expected.Digest.Encoded()[0:8], clearErr)
+			}
+			return "", fmt.Errorf("upload session no longer exists on remote registry")
+		}
+		if resp.StatusCode != http.StatusAccepted {
+			defer resp.Body.Close()
+			return "", handleRemoteError(resp)
+		}
+		resp.Body.Close()
+
+		respLocation, err := resp.Location()
+		if err != nil {
+			return "", fmt.Errorf("missing Location header in response")
+		}
+		nextLocation = respLocation
+
+		state := uploads.State{
+			Digest:       expected.Digest.String(),
+			StartingURL:  location.String(),
+			NextLocation: nextLocation.String(),
+			LastRangeEnd: rangeEnd,
+			ChunkSize:    chunkSize,
+		}
+		if err := uploads.Save(r.CacheDir, key, state); err != nil {
+			output.SafeDebugf("[%s] Failed to persist resumable upload state: %s", expected.Digest.Encoded()[0:8], err)
+		}
+
+		rangeStart = rangeEnd + 1
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, nextLocation.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	q := req.URL.Query()
+	q.Set("digest", expected.Digest.String())
+	req.URL.RawQuery = q.Encode()
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	output.SafeDebugf("[%s] Finalizing upload", expected.Digest.Encoded()[0:8])
+	resp, err := r.client().Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to finalize blob upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", handleRemoteError(resp)
+	}
+
+	if err := uploads.Clear(r.CacheDir, key); err != nil {
+		output.SafeDebugf("[%s] Failed to clear upload state after completing upload: %s", expected.Digest.Encoded()[0:8], err)
+	}
+
+	blobLocation, err := resp.Location()
+	if err != nil {
+		output.Errorf("Warning: remote registry did not return blob location")
+	}
+	return blobLocation.String(), nil
+}
+
 func (r *Repository) uploadBlobChunkWithRetry(ctx context.Context, req *http.Request, content io.Reader, expected ocispec.Descriptor, rangeStart, rangeEnd int64) (*http.Response, error) {
 	seekableContent, isSeekable := content.(io.Seeker)
 
@@ -380,6 +1063,14 @@ func buildRepositoryBlobUploadURL(plainHTTP bool, ref registry.Reference) string
 	return fmt.Sprintf("%s://%s/v2/%s/blobs/uploads/", scheme, ref.Host(), ref.Repository)
 }
 
+func buildRepositoryBlobURL(plainHTTP bool, ref registry.Reference, digest string) string {
+	scheme := "https"
+	if plainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/blobs/%s", scheme, ref.Host(), ref.Repository, digest)
+}
+
 func buildRepositoryManifestsURL(plainHTTP bool, registryRef registry.Reference, manifestRef string) string {
 	scheme := "https"
 	if plainHTTP {
@@ -28,6 +28,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kitops-ml/kitops/pkg/cache/uploads"
 	"github.com/kitops-ml/kitops/pkg/output"
 
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -66,6 +67,141 @@ func setup(logbuf *bytes.Buffer) (teardown func()) {
 	}
 }
 
+func TestMountBlobSuccess(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: 2}
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			// HEAD preflight confirms otherrepo actually has the blob before mountBlob
+			// spends a mount attempt on it.
+			if err := processRequest(req, http.MethodHead, "/v2/otherrepo/blobs/"+expectedDigest.String()); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusOK, "", nil)
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPost, "/v2/testrepo/blobs/uploads/"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, expectedDigest.String(), req.URL.Query().Get("mount"))
+			assert.Equal(t, "otherrepo", req.URL.Query().Get("from"))
+			return makeResponse(req, http.StatusCreated, "", map[string]string{
+				"Location": "/v2/testrepo/blobs/" + expectedDigest.String(),
+			})
+		},
+	}
+
+	tc := &testClient{responses: responses}
+	testRepo := Repository{
+		Repository: nil, // Not testing library functionality here!
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	mounted, location, fallbackSession, fallbackPostResp, err := testRepo.mountBlob(t.Context(), expectedDesc, []string{"otherrepo"})
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, mounted, "should have mounted blob")
+	assert.Equal(t, "http://testreg/v2/testrepo/blobs/"+expectedDigest.String(), location)
+	assert.Nil(t, fallbackSession)
+	assert.Nil(t, fallbackPostResp)
+}
+
+func TestMountBlobSkipsCandidateWithoutBlob(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: 2}
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			// otherrepo doesn't have the blob; mountBlob should skip straight to the
+			// next candidate without ever POSTing a mount attempt against it.
+			if err := processRequest(req, http.MethodHead, "/v2/otherrepo/blobs/"+expectedDigest.String()); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusNotFound, "", nil)
+		},
+	}
+
+	tc := &testClient{responses: responses}
+	testRepo := Repository{
+		Repository: nil, // Not testing library functionality here!
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	mounted, location, fallbackSession, fallbackPostResp, err := testRepo.mountBlob(t.Context(), expectedDesc, []string{"otherrepo"})
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, mounted, "should not attempt to mount a blob the candidate doesn't have")
+	assert.Equal(t, "", location)
+	assert.Nil(t, fallbackSession)
+	assert.Nil(t, fallbackPostResp)
+}
+
+func TestMountBlobReusesSessionWhenDeclined(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: 2}
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodHead, "/v2/otherrepo/blobs/"+expectedDigest.String()); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusOK, "", nil)
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPost, "/v2/testrepo/blobs/uploads/"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "otherrepo", req.URL.Query().Get("from"))
+			// Registry has mounting disabled; falls back to a normal upload session
+			// instead of mounting, even though otherrepo does have the blob.
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/v2/testrepo/blobs/uploads/session",
+			})
+		},
+	}
+
+	tc := &testClient{responses: responses}
+	testRepo := Repository{
+		Repository: nil, // Not testing library functionality here!
+		Reference:  registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:  true,
+		Client:     tc,
+	}
+
+	mounted, location, fallbackSession, fallbackPostResp, err := testRepo.mountBlob(t.Context(), expectedDesc, []string{"otherrepo"})
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, mounted, "should not report a mount when registry declines it")
+	assert.Equal(t, "", location)
+	if assert.NotNil(t, fallbackSession, "should return the session the registry opened instead of discarding it") {
+		assert.Equal(t, "http://testreg/v2/testrepo/blobs/uploads/session", fallbackSession.String())
+	}
+	assert.NotNil(t, fallbackPostResp)
+}
+
 func TestUploadBlobChunked(t *testing.T) {
 	var logbuf bytes.Buffer
 	teardown := setup(&logbuf)
@@ -132,7 +268,7 @@ func TestUploadBlobChunked(t *testing.T) {
 		uploadChunkSize: uploadChunkDefaultSize,
 	}
 
-	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, "", expectedDesc, testContent)
+	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, 0)
 	t.Logf("Function output:\n%s\n", logbuf.String())
 	if !assert.NoError(t, tErr) {
 		return
@@ -201,7 +337,7 @@ func TestUploadBlobChunkedVerifyRequestHeaders(t *testing.T) {
 		uploadChunkSize: uploadChunkDefaultSize,
 	}
 
-	_, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, expectedAuthHeader, expectedDesc, testContent)
+	_, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(expectedAuthHeader), expectedDesc, testContent, 0)
 	t.Logf("Function output:\n%s\n", logbuf.String())
 	assert.ErrorIs(t, tErr, completedErr, "Unexpected error returned")
 }
@@ -322,7 +458,7 @@ func TestUploadBlobChunkedRetries(t *testing.T) {
 		uploadChunkSize: testChunkSize,
 	}
 
-	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, "", expectedDesc, testContent)
+	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, 0)
 	t.Logf("Function output:\n%s\n", logbuf.String())
 	if !assert.NoError(t, tErr) {
 		return
@@ -416,7 +552,7 @@ func TestUploadBlobChunkedRetriesLimit(t *testing.T) {
 		uploadChunkSize: testChunkSize,
 	}
 
-	_, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, "", expectedDesc, testContent)
+	_, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, 0)
 	t.Logf("Function output:\n%s\n", logbuf.String())
 	if !assert.Error(t, tErr, "Expected an error to be returned") {
 		return
@@ -424,6 +560,719 @@ func TestUploadBlobChunkedRetriesLimit(t *testing.T) {
 	assert.ErrorContains(t, tErr, "end of test", "Unexpected error returned")
 }
 
+func TestUploadBlobChunkedMinChunkLength(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/one")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testChunkSize int64 = 5
+	var minChunkSize int64 = 10
+
+	expectedSize := 2 * minChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	testContent := io.LimitReader(rand.Reader, expectedSize)
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			// The registry-advertised minimum chunk length is larger than our
+			// configured default, so it should win.
+			assert.Equal(t, fmt.Sprintf("0-%d", minChunkSize-1), req.Header.Get("Content-Range"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/two",
+				"Range":    fmt.Sprintf("0-%d", minChunkSize-1),
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/two"); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/three",
+				"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPut, "/three"); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusCreated, "", map[string]string{
+				"Location": "finalLocation",
+			})
+		},
+	}
+
+	tc := &testClient{
+		responses: responses,
+	}
+
+	testRepo := Repository{
+		Repository:      nil, // Not testing library functionality here!
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, minChunkSize)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation, "Should return location in last response")
+}
+
+func TestUploadBlobChunkedPartialAcceptance(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/one")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testChunkSize int64 = 10
+
+	expectedSize := 2 * testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	testContent := bytes.NewReader(buf.Bytes())
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "0-9", req.Header.Get("Content-Range"))
+			// Registry only accepted the first 5 of the 10 bytes we sent.
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/two",
+				"Range":    "0-4",
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/two"); err != nil {
+				return nil, err
+			}
+			// Should resume at byte 5, not byte 10.
+			assert.Equal(t, "5-14", req.Header.Get("Content-Range"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/three",
+				"Range":    "0-14",
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/three"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "15-19", req.Header.Get("Content-Range"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/four",
+				"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPut, "/four"); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusCreated, "", map[string]string{
+				"Location": "finalLocation",
+			})
+		},
+	}
+
+	tc := &testClient{
+		responses: responses,
+	}
+
+	testRepo := Repository{
+		Repository:      nil, // Not testing library functionality here!
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, 0)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation, "Should return location in last response")
+}
+
+func TestUploadBlobChunkedShrinksOnTooLarge(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/one")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testChunkSize int64 = 8
+
+	expectedSize := testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	testContent := bytes.NewReader(buf.Bytes())
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			// Chunk size 8 is larger than the whole blob; rejected as too large.
+			assert.Equal(t, "0-7", req.Header.Get("Content-Range"))
+			return makeResponse(req, http.StatusRequestEntityTooLarge, "", nil)
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			// Halved to 4; accepted.
+			assert.Equal(t, "0-3", req.Header.Get("Content-Range"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/two",
+				"Range":    "0-3",
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/two"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "4-7", req.Header.Get("Content-Range"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/three",
+				"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPut, "/three"); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusCreated, "", map[string]string{
+				"Location": "finalLocation",
+			})
+		},
+	}
+
+	tc := &testClient{
+		responses: responses,
+	}
+
+	testRepo := Repository{
+		Repository:      nil, // Not testing library functionality here!
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, 0)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation, "Should return location in last response")
+}
+
+// TestUploadBlobChunkedRecoversFrom416 checks that a 416 Range Not Satisfiable mid-
+// upload -- distinct from 413, which just means "too large" -- resyncs to the offset
+// the registry reports via its own Range header rather than blindly retrying the same
+// range with a smaller chunk size.
+func TestUploadBlobChunkedRecoversFrom416(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/one")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testChunkSize int64 = 4
+	expectedSize := int64(8)
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	testContent := bytes.NewReader(buf.Bytes())
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "0-3", req.Header.Get("Content-Range"))
+			// The registry claims it already has up through byte 5, well past what we
+			// just tried to send; it must have durably accepted more of a previous
+			// attempt than its earlier response let on.
+			return makeResponse(req, http.StatusRequestedRangeNotSatisfiable, "", map[string]string{
+				"Range": "0-5",
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			// Resynced to start at byte 6, per the 416's Range header.
+			assert.Equal(t, "6-7", req.Header.Get("Content-Range"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/two",
+				"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPut, "/two"); err != nil {
+				return nil, err
+			}
+			return makeResponse(req, http.StatusCreated, "", map[string]string{
+				"Location": "finalLocation",
+			})
+		},
+	}
+
+	tc := &testClient{responses: responses}
+	testRepo := Repository{
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, 0)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation)
+}
+
+// TestUploadBlobChunkedFailsOn416WithoutRange checks that a 416 with no Range header --
+// which can't be resynced to anything -- surfaces as an error instead of looping
+// forever or silently resending the same range.
+func TestUploadBlobChunkedFailsOn416WithoutRange(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/one")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSize := int64(8)
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	testContent := bytes.NewReader(buf.Bytes())
+
+	tc := &testClient{
+		responses: []func(*http.Request) (*http.Response, error){
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusRequestedRangeNotSatisfiable, "", nil)
+			},
+		},
+	}
+	testRepo := Repository{
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: expectedSize,
+	}
+
+	_, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager(""), expectedDesc, testContent, 0)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	assert.Error(t, tErr)
+}
+
+func TestUploadBlobChunkedRefreshesExpiredToken(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	startUrl, err := url.Parse("http://127.0.0.1/one")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testChunkSize int64 = 5
+	expectedSize := 2 * testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	testContent := bytes.NewReader(buf.Bytes())
+
+	challenge := `Bearer realm="http://127.0.0.1/token",service="testreg",scope="repository:testrepo:pull,push"`
+
+	responses := []func(*http.Request) (*http.Response, error){
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "stale-token", req.Header.Get("Authorization"))
+			return makeResponse(req, http.StatusUnauthorized, "", map[string]string{
+				"WWW-Authenticate": challenge,
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if req.Method != http.MethodGet || req.URL.Path != "/token" {
+				return nil, fmt.Errorf("expected token request, got %s %s", req.Method, req.URL.Path)
+			}
+			assert.Equal(t, "testreg", req.URL.Query().Get("service"))
+			assert.Equal(t, "repository:testrepo:pull,push", req.URL.Query().Get("scope"))
+			return makeResponse(req, http.StatusOK, `{"token":"fresh-token"}`, nil)
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+				return nil, err
+			}
+			// Retried chunk should use the refreshed token.
+			assert.Equal(t, "Bearer fresh-token", req.Header.Get("Authorization"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/two",
+				"Range":    fmt.Sprintf("0-%d", testChunkSize-1),
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPatch, "/two"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "Bearer fresh-token", req.Header.Get("Authorization"))
+			return makeResponse(req, http.StatusAccepted, "", map[string]string{
+				"Location": "/three",
+				"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+			})
+		},
+		func(req *http.Request) (*http.Response, error) {
+			if err := processRequest(req, http.MethodPut, "/three"); err != nil {
+				return nil, err
+			}
+			assert.Equal(t, "Bearer fresh-token", req.Header.Get("Authorization"))
+			return makeResponse(req, http.StatusCreated, "", map[string]string{
+				"Location": "finalLocation",
+			})
+		},
+	}
+
+	tc := &testClient{
+		responses: responses,
+	}
+
+	testRepo := Repository{
+		Repository:      nil, // Not testing library functionality here!
+		Reference:       registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"},
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+	}
+
+	finalLocation, tErr := testRepo.uploadBlobChunked(t.Context(), startUrl, NewChallengeManager("stale-token"), expectedDesc, testContent, 0)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://127.0.0.1/finalLocation", finalLocation, "Should return location in last response")
+}
+
+func TestUploadBlobResume(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	retryPolicy = &retry.GenericPolicy{
+		Retryable: retry.DefaultPredicate,
+		Backoff:   retry.DefaultBackoff,
+		MinWait:   10 * time.Millisecond,
+		MaxWait:   30 * time.Millisecond,
+		MaxRetry:  2,
+	}
+	defer func() {
+		retryPolicy = retry.DefaultPolicy
+	}()
+
+	var testChunkSize int64 = 5
+	expectedSize := 3 * testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	content := bytes.NewReader(buf.Bytes())
+
+	cacheDir := t.TempDir()
+	ref := registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"}
+
+	// First "run": upload one chunk, then simulate the process being killed before the
+	// session completes. No final PUT is ever issued.
+	firstRun := &testClient{
+		responses: []func(*http.Request) (*http.Response, error){
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPost, "/v2/testrepo/blobs/uploads/"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{"Location": "/one"})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/two",
+					"Range":    fmt.Sprintf("0-%d", testChunkSize-1),
+				})
+			},
+		},
+	}
+	repo1 := &Repository{
+		Reference:       ref,
+		PlainHttp:       true,
+		Client:          firstRun,
+		uploadChunkSize: testChunkSize,
+		CacheDir:        cacheDir,
+	}
+	_, tErr := repo1.uploadBlobResume(t.Context(), expectedDesc, content)
+	assert.Error(t, tErr, "expected the simulated kill (exhausted responses) to surface as an error")
+
+	// "Restart": a fresh Repository pointed at the same cache dir picks up the
+	// persisted state, confirms the registry's offset with a GET, and resumes from
+	// the second chunk rather than re-uploading the first.
+	secondRun := &testClient{
+		responses: []func(*http.Request) (*http.Response, error){
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodGet, "/two"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusNoContent, "", map[string]string{
+					"Range": fmt.Sprintf("0-%d", testChunkSize-1),
+				})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/two"); err != nil {
+					return nil, err
+				}
+				expectedContentRange := fmt.Sprintf("%d-%d", testChunkSize, 2*testChunkSize-1)
+				assert.Equal(t, expectedContentRange, req.Header.Get("Content-Range"))
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/three",
+					"Range":    fmt.Sprintf("0-%d", 2*testChunkSize-1),
+				})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/three"); err != nil {
+					return nil, err
+				}
+				expectedContentRange := fmt.Sprintf("%d-%d", 2*testChunkSize, expectedSize-1)
+				assert.Equal(t, expectedContentRange, req.Header.Get("Content-Range"))
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/four",
+					"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+				})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPut, "/four"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "finalLocation"})
+			},
+		},
+	}
+	repo2 := &Repository{
+		Reference:       ref,
+		PlainHttp:       true,
+		Client:          secondRun,
+		uploadChunkSize: testChunkSize,
+		CacheDir:        cacheDir,
+	}
+	finalLocation, tErr := repo2.uploadBlobResume(t.Context(), expectedDesc, content)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	if !assert.NoError(t, tErr) {
+		return
+	}
+	assert.Equal(t, "http://testreg/finalLocation", finalLocation)
+
+	// Once the upload completes, its resume state must be cleared so it can't wedge a
+	// future push into resuming from a now-meaningless location.
+	_, ok, err := uploads.Load(cacheDir, repo2.uploadKey(expectedDesc))
+	assert.NoError(t, err)
+	assert.False(t, ok, "upload state should be cleared once the upload completes")
+}
+
+func TestUploadBlobResumeExpiredSession(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	var testChunkSize int64 = 5
+	expectedSize := 2 * testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	content := bytes.NewReader(buf.Bytes())
+
+	cacheDir := t.TempDir()
+	ref := registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"}
+	key := uploads.Key{Registry: ref.Registry, Repository: ref.Repository, Digest: expectedDigest.String()}
+	assert.NoError(t, uploads.Save(cacheDir, key, uploads.State{
+		Digest:       expectedDigest.String(),
+		NextLocation: "http://127.0.0.1/stale",
+		ChunkSize:    testChunkSize,
+	}))
+
+	tc := &testClient{
+		responses: []func(*http.Request) (*http.Response, error){
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodGet, "/stale"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusNotFound, "", nil)
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPost, "/v2/testrepo/blobs/uploads/"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{"Location": "/fresh-one"})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/fresh-one"); err != nil {
+					return nil, err
+				}
+				expectedContentRange := fmt.Sprintf("0-%d", testChunkSize-1)
+				assert.Equal(t, expectedContentRange, req.Header.Get("Content-Range"), "should restart from byte zero, not the stale offset")
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/fresh-two",
+					"Range":    fmt.Sprintf("0-%d", testChunkSize-1),
+				})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/fresh-two"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/fresh-three",
+					"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+				})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPut, "/fresh-three"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "finalLocation"})
+			},
+		},
+	}
+	repo := &Repository{
+		Reference:       ref,
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+		CacheDir:        cacheDir,
+	}
+	_, tErr := repo.uploadBlobResume(t.Context(), expectedDesc, content)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	assert.NoError(t, tErr)
+}
+
+// TestUploadBlobResumeDisableResume checks that DisableResume skips the GET status
+// check entirely (going straight to a fresh upload session) even when resumable state
+// is on disk for the blob being pushed, and clears that now-stale state rather than
+// leaving it to confuse a later, resume-enabled push.
+func TestUploadBlobResumeDisableResume(t *testing.T) {
+	var logbuf bytes.Buffer
+	teardown := setup(&logbuf)
+	defer teardown()
+
+	var testChunkSize int64 = 10
+	expectedSize := 2 * testChunkSize
+	expectedDigest := ocispec.DescriptorEmptyJSON.Digest
+	expectedDesc := ocispec.Descriptor{Digest: expectedDigest, Size: expectedSize}
+	var buf bytes.Buffer
+	io.CopyN(&buf, rand.Reader, expectedSize)
+	content := bytes.NewReader(buf.Bytes())
+
+	cacheDir := t.TempDir()
+	ref := registry.Reference{Registry: "testreg", Repository: "testrepo", Reference: "testtag"}
+	key := uploads.Key{Registry: ref.Registry, Repository: ref.Repository, Digest: expectedDigest.String()}
+	assert.NoError(t, uploads.Save(cacheDir, key, uploads.State{
+		Digest:       expectedDigest.String(),
+		NextLocation: "http://127.0.0.1/stale",
+		ChunkSize:    testChunkSize,
+	}))
+
+	tc := &testClient{
+		responses: []func(*http.Request) (*http.Response, error){
+			func(req *http.Request) (*http.Response, error) {
+				// No GET to /stale: DisableResume must start fresh instead of checking it.
+				if err := processRequest(req, http.MethodPost, "/v2/testrepo/blobs/uploads/"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{"Location": "/one"})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/one"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/two",
+					"Range":    fmt.Sprintf("0-%d", testChunkSize-1),
+				})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPatch, "/two"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusAccepted, "", map[string]string{
+					"Location": "/three",
+					"Range":    fmt.Sprintf("0-%d", expectedSize-1),
+				})
+			},
+			func(req *http.Request) (*http.Response, error) {
+				if err := processRequest(req, http.MethodPut, "/three"); err != nil {
+					return nil, err
+				}
+				return makeResponse(req, http.StatusCreated, "", map[string]string{"Location": "finalLocation"})
+			},
+		},
+	}
+	repo := &Repository{
+		Reference:       ref,
+		PlainHttp:       true,
+		Client:          tc,
+		uploadChunkSize: testChunkSize,
+		CacheDir:        cacheDir,
+		DisableResume:   true,
+	}
+	_, tErr := repo.uploadBlobResume(t.Context(), expectedDesc, content)
+	t.Logf("Function output:\n%s\n", logbuf.String())
+	assert.NoError(t, tErr)
+
+	_, ok, err := uploads.Load(cacheDir, key)
+	assert.NoError(t, err)
+	assert.False(t, ok, "stale state should have been cleared rather than left behind")
+}
+
 func gobbleBody(req *http.Request) error {
 	if req.Body == nil {
 		return nil
@@ -0,0 +1,105 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kitops-ml/kitops/pkg/lib/constants/mediatype"
+	"github.com/kitops-ml/kitops/pkg/output"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ResolveManifest resolves reference against r and returns the single image manifest a
+// `kit pull`/`kit unpack` should act on, normalizing two shapes a registry may hand
+// back into the one ocispec.Manifest the rest of this tree understands:
+//
+//   - An OCI image index (a multi-platform/multi-variant ModelKit) is resolved down to
+//     a single child manifest via mediatype.ResolveIndexVariant, using variant if set,
+//     else platform, then that child is fetched and decoded in turn.
+//   - A deprecated OCI artifact manifest (mediatype.ArtifactManifestMediaType) is
+//     decoded and translated via mediatype.ManifestFromArtifact.
+//
+// A plain image manifest is just decoded and returned.
+//
+// The returned descriptor is always the one for the manifest actually returned -- the
+// child's, not the index's, when reference names an index.
+func (r *Repository) ResolveManifest(ctx context.Context, reference string, variant string, platform *ocispec.Platform) (ocispec.Descriptor, *ocispec.Manifest, error) {
+	desc, err := r.Resolve(ctx, reference)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to resolve %s: %w", reference, err)
+	}
+	return r.resolveManifestDescriptor(ctx, desc, variant, platform)
+}
+
+// resolveManifestDescriptor fetches and decodes desc, resolving OCI image index
+// indirection if desc turns out to be one; a child that is itself an index is resolved
+// the same way, recursively.
+func (r *Repository) resolveManifestDescriptor(ctx context.Context, desc ocispec.Descriptor, variant string, platform *ocispec.Platform) (ocispec.Descriptor, *ocispec.Manifest, error) {
+	raw, err := r.fetchManifestBytes(ctx, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, nil, err
+	}
+
+	if mediatype.IsImageIndex(desc.MediaType) {
+		var index ocispec.Index
+		if err := json.Unmarshal(raw, &index); err != nil {
+			return ocispec.Descriptor{}, nil, fmt.Errorf("failed to parse image index %s: %w", desc.Digest, err)
+		}
+		childDesc, err := mediatype.ResolveIndexVariant(&index, variant, platform)
+		if err != nil {
+			return ocispec.Descriptor{}, nil, fmt.Errorf("failed to resolve manifest in index %s: %w", desc.Digest, err)
+		}
+		return r.resolveManifestDescriptor(ctx, childDesc, variant, platform)
+	}
+
+	if desc.MediaType == mediatype.ArtifactManifestMediaType {
+		var artifact mediatype.ArtifactManifest
+		if err := json.Unmarshal(raw, &artifact); err != nil {
+			return ocispec.Descriptor{}, nil, fmt.Errorf("failed to parse artifact manifest %s: %w", desc.Digest, err)
+		}
+		output.SafeDebugf("[%s] Translating deprecated OCI artifact manifest to an image manifest", desc.Digest.Encoded()[0:8])
+		return desc, mediatype.ManifestFromArtifact(&artifact), nil
+	}
+
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return ocispec.Descriptor{}, nil, fmt.Errorf("failed to parse manifest %s: %w", desc.Digest, err)
+	}
+	return desc, &manifest, nil
+}
+
+// fetchManifestBytes fetches and fully reads desc's content, for the manifest-sized
+// payloads ResolveManifest deals with where buffering the whole body is the simplest
+// option -- unlike the (potentially multi-gigabyte) layer blobs Fetch/FetchFrom are
+// built to stream.
+func (r *Repository) fetchManifestBytes(ctx context.Context, desc ocispec.Descriptor) ([]byte, error) {
+	rc, err := r.Fetch(ctx, desc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest %s: %w", desc.Digest, err)
+	}
+	defer rc.Close()
+	raw, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", desc.Digest, err)
+	}
+	return raw, nil
+}
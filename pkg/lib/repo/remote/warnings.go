@@ -0,0 +1,94 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kitops-ml/kitops/pkg/output"
+)
+
+// RegistryWarning is a single Warning response header, as distribution-spec registries
+// (Harbor, GHCR, ...) use to surface deprecation notices and quota-nearing messages
+// during a push, per RFC 7234 §5.5:
+//
+//	Warning: <code> <agent> "<text>" [<date>]
+//
+// References:
+//   - https://github.com/opencontainers/distribution-spec/blob/v1.1.0-rc4/spec.md#warnings
+//   - https://www.rfc-editor.org/rfc/rfc7234#section-5.5
+type RegistryWarning struct {
+	Code  int
+	Agent string
+	Text  string
+	Date  time.Time
+}
+
+// parseWarnings decodes every Warning header on resp. A header that doesn't match the
+// RFC 7234 grammar is skipped rather than causing the whole response to fail -- a
+// malformed warning is never worth failing a push over.
+func parseWarnings(resp *http.Response) []RegistryWarning {
+	var warnings []RegistryWarning
+	for _, raw := range resp.Header.Values("Warning") {
+		if w, ok := parseWarning(raw); ok {
+			warnings = append(warnings, w)
+		}
+	}
+	return warnings
+}
+
+func parseWarning(raw string) (RegistryWarning, bool) {
+	fields := strings.SplitN(strings.TrimSpace(raw), " ", 3)
+	if len(fields) != 3 {
+		return RegistryWarning{}, false
+	}
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return RegistryWarning{}, false
+	}
+	agent := fields[1]
+
+	rest := strings.TrimSpace(fields[2])
+	if !strings.HasPrefix(rest, `"`) {
+		return RegistryWarning{}, false
+	}
+	rest = rest[1:]
+	endQuote := strings.IndexByte(rest, '"')
+	if endQuote < 0 {
+		return RegistryWarning{}, false
+	}
+	w := RegistryWarning{Code: code, Agent: agent, Text: rest[:endQuote]}
+
+	if dateField := strings.Trim(strings.TrimSpace(rest[endQuote+1:]), `"`); dateField != "" {
+		if t, err := http.ParseTime(dateField); err == nil {
+			w.Date = t
+		}
+	}
+	return w, true
+}
+
+// surfaceWarnings routes every Warning header on resp through output.RegistryWarnf, so
+// a deprecation or quota notice a registry sends back mid-push reaches the user instead
+// of being silently dropped along with the rest of the response.
+func surfaceWarnings(resp *http.Response) {
+	for _, w := range parseWarnings(resp) {
+		output.RegistryWarnf("registry warning %d: %s", w.Code, w.Text)
+	}
+}
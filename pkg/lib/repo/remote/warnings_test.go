@@ -0,0 +1,86 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWarning(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		want   RegistryWarning
+		wantOk bool
+	}{
+		{
+			name:   "without date",
+			raw:    `299 registry.example.com "This API is deprecated, please upgrade"`,
+			want:   RegistryWarning{Code: 299, Agent: "registry.example.com", Text: "This API is deprecated, please upgrade"},
+			wantOk: true,
+		},
+		{
+			name: "with date",
+			raw:  `299 - "Approaching storage quota" "Sun, 06 Nov 1994 08:49:37 GMT"`,
+			want: RegistryWarning{
+				Code: 299, Agent: "-", Text: "Approaching storage quota",
+				Date: time.Date(1994, 11, 6, 8, 49, 37, 0, time.UTC),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "missing quotes",
+			raw:    `299 registry.example.com deprecated`,
+			wantOk: false,
+		},
+		{
+			name:   "non-numeric code",
+			raw:    `warn registry.example.com "deprecated"`,
+			wantOk: false,
+		},
+		{
+			name:   "too few fields",
+			raw:    `299 registry.example.com`,
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseWarning(tt.raw)
+			assert.Equal(t, tt.wantOk, ok)
+			if tt.wantOk {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseWarnings(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Add("Warning", `299 registry.example.com "first warning"`)
+	resp.Header.Add("Warning", `299 registry.example.com "second warning"`)
+	resp.Header.Add("Warning", `not a warning`)
+
+	warnings := parseWarnings(resp)
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, "first warning", warnings[0].Text)
+	assert.Equal(t, "second warning", warnings[1].Text)
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The KitOps Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	quiet          bool
+	seenWarningsMu sync.Mutex
+	seenWarnings   = map[string]bool{}
+)
+
+// SetQuiet controls whether RegistryWarnf demotes its messages to debug level instead
+// of info, for a `--quiet` flag.
+func SetQuiet(q bool) {
+	quiet = q
+}
+
+// RegistryWarnf surfaces a message from a remote registry (e.g. a distribution-spec
+// Warning header) to stderr at info level, or debug if SetQuiet(true) was called.
+// Identical messages print only once per process: a multi-gigabyte chunked upload can
+// otherwise repeat the same deprecation or quota notice on every PATCH, which would
+// bury it rather than draw attention to it.
+func RegistryWarnf(format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+
+	seenWarningsMu.Lock()
+	alreadySeen := seenWarnings[msg]
+	seenWarnings[msg] = true
+	seenWarningsMu.Unlock()
+	if alreadySeen {
+		return
+	}
+
+	level := LogLevelInfo
+	if quiet {
+		level = LogLevelDebug
+	}
+	SafeLogf(level, "%s", msg)
+}